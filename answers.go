@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"aoc2024/shared/solver"
+)
+
+type answers struct {
+	Part1 solver.Result `json:"part1"`
+	Part2 solver.Result `json:"part2"`
+}
+
+func (a answers) part(partNr int) solver.Result {
+	if partNr == 1 {
+		return a.Part1
+	}
+	return a.Part2
+}
+
+func answersPath(inputPath string) string {
+	return filepath.Join(filepath.Dir(inputPath), "answers.json")
+}
+
+// loadAnswers reads the recorded answers for a day. A missing file means no answers are known yet.
+func loadAnswers(path string) (answers, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers{}, nil
+	}
+	if err != nil {
+		return answers{}, err
+	}
+
+	var a answers
+	if err := json.Unmarshal(data, &a); err != nil {
+		return answers{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return a, nil
+}
+
+func saveAnswers(path string, report dayReport) error {
+	a := answers{Part1: report.parts[0].answer, Part2: report.parts[1].answer}
+
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"aoc2024/shared/solver"
+)
+
+type stageBench struct {
+	name      string
+	durations []time.Duration
+	allocs    uint64
+	bytes     uint64
+}
+
+func (s *stageBench) record(d time.Duration, allocs, bytes uint64) {
+	s.durations = append(s.durations, d)
+	s.allocs += allocs
+	s.bytes += bytes
+}
+
+func (s *stageBench) runs() int {
+	return len(s.durations)
+}
+
+func (s *stageBench) percentile(p int) time.Duration {
+	sorted := slices.Clone(s.durations)
+	slices.Sort(sorted)
+	return sorted[(len(sorted)-1)*p/100]
+}
+
+func (s *stageBench) allocsPerRun() uint64 {
+	return s.allocs / uint64(s.runs())
+}
+
+func (s *stageBench) bytesPerRun() uint64 {
+	return s.bytes / uint64(s.runs())
+}
+
+type dayBench struct {
+	day    int
+	stages []*stageBench
+}
+
+func measure(f func() error) (time.Duration, uint64, uint64, error) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	err := protect(f)
+	d := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return d, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc, err
+}
+
+func benchDay(ctx context.Context, day int, path string, runs int) (dayBench, error) {
+	s, ok := solver.Get(day)
+	if !ok {
+		return dayBench{}, &dayError{day: day, stage: stageInput, err: errors.New("no solver registered")}
+	}
+
+	r, err := openInput(path)
+	if err != nil {
+		return dayBench{}, &dayError{day: day, stage: stageInput, err: err}
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return dayBench{}, &dayError{day: day, stage: stageInput, err: err}
+	}
+
+	parse := &stageBench{name: "parse"}
+	parts := []struct {
+		stage *stageBench
+		id    stage
+		run   func(context.Context, any) (solver.Result, error)
+	}{
+		{&stageBench{name: "part1"}, stagePart1, s.Part1},
+		{&stageBench{name: "part2"}, stagePart2, s.Part2},
+	}
+
+	var input any
+	for run := range runs {
+		d, allocs, bytesAllocated, err := measure(func() error {
+			_, err := watch(ctx, func() error {
+				var err error
+				input, err = s.Parse(bytes.NewReader(data))
+				return err
+			})
+			return err
+		})
+		if errors.Is(err, errTimeout) || errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w after %d of %d runs", errTimeout, run, runs)
+		}
+		if err != nil {
+			return dayBench{}, &dayError{day: day, stage: stageParse, err: err}
+		}
+		parse.record(d, allocs, bytesAllocated)
+
+		for _, part := range parts {
+			d, allocs, bytesAllocated, err := measure(func() error {
+				return runPart(ctx, part.run, input).err
+			})
+			if errors.Is(err, solver.ErrNoSolution) {
+				continue
+			}
+			if errors.Is(err, errTimeout) || errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("%w after %d of %d runs", errTimeout, run, runs)
+			}
+			if err != nil {
+				return dayBench{}, &dayError{day: day, stage: part.id, err: err}
+			}
+			part.stage.record(d, allocs, bytesAllocated)
+		}
+	}
+
+	result := dayBench{day: day, stages: []*stageBench{parse}}
+	for _, part := range parts {
+		if part.stage.runs() > 0 {
+			result.stages = append(result.stages, part.stage)
+		}
+	}
+	return result, nil
+}
+
+func printBenchTable(w io.Writer, benches []dayBench) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tw, "Day\tStage\tRuns\tMin\tMedian\tP95\tAllocs/op\tB/op\t")
+
+	for _, b := range benches {
+		for _, s := range b.stages {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t\n",
+				b.day, s.name, s.runs(),
+				formatDuration(s.percentile(0)),
+				formatDuration(s.percentile(50)),
+				formatDuration(s.percentile(95)),
+				s.allocsPerRun(), s.bytesPerRun(),
+			)
+		}
+	}
+
+	return tw.Flush()
+}
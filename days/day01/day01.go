@@ -1,13 +1,20 @@
 package day01
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
+	"slices"
 	"sort"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
+type input struct {
+	list1 []int
+	list2 []int
+}
+
 func parseLists(rawInput [][]int) ([]int, []int, error) {
 	var list1, list2 []int
 
@@ -24,7 +31,8 @@ func sortLists(list1 []int, list2 []int) {
 	sort.Ints(list2)
 }
 
-func part1(list1 []int, list2 []int) {
+func part1(_ context.Context, in input) (int, error) {
+	list1, list2 := slices.Clone(in.list1), slices.Clone(in.list2)
 	sortLists(list1, list2)
 
 	totalDistances := 0
@@ -32,35 +40,36 @@ func part1(list1 []int, list2 []int) {
 		totalDistances += shared.AbsInt(list1[i] - list2[i])
 	}
 
-	fmt.Println("Part 1:", totalDistances)
+	return totalDistances, nil
 }
 
-func part2(list1 []int, list2 []int) {
+func part2(_ context.Context, in input) (int, error) {
 	counts := make(map[int]int)
-	for _, val := range list2 {
+	for _, val := range in.list2 {
 		counts[val]++
 	}
 
 	simScore := 0
-	for _, val := range list1 {
+	for _, val := range in.list1 {
 		simScore += val * counts[val]
 	}
 
-	fmt.Println("Part 2:", simScore)
+	return simScore, nil
 }
 
-func Run() {
-	rawInput, err := shared.ReadFileByLineToSplitInts("days/day01/input.txt", "   ")
+func parse(r io.Reader) (input, error) {
+	rawInput, err := shared.ReadByLineToSplitInts(r, "   ")
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 	list1, list2, err := parseLists(rawInput)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
-	part1(list1, list2)
-	part2(list1, list2)
+	return input{list1: list1, list2: list2}, nil
+}
+
+func init() {
+	solver.Register(1, solver.New(parse, part1, part2))
 }
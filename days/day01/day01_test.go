@@ -0,0 +1,55 @@
+package day01
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `3   4
+4   3
+2   5
+1   3
+3   9
+3   3`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, input) (int, error)
+		want int
+	}{
+		{name: "part 1", part: part1, want: 11},
+		{name: "part 2", part: part2, want: 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestPart1DoesNotModifyInput(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	if _, err := part1(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in.list1[0] != 3 || in.list2[0] != 4 {
+		t.Errorf("part1 sorted the parsed lists: %v %v", in.list1, in.list2)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
@@ -1,10 +1,11 @@
 package day02
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 func sameSign(a int, b int) bool {
@@ -89,33 +90,30 @@ func isSafeWithDampener(report []int) bool {
 	return true
 }
 
-func part1(reports [][]int) {
+func part1(_ context.Context, reports [][]int) (int, error) {
 	count := 0
 	for _, report := range reports {
 		if isSafe(report) {
 			count++
 		}
 	}
-	fmt.Println("Part 1:", count)
+	return count, nil
 }
 
-func part2(reports [][]int) {
+func part2(_ context.Context, reports [][]int) (int, error) {
 	count := 0
 	for _, report := range reports {
 		if isSafeWithDampener(report) {
 			count++
 		}
 	}
-	fmt.Println("Part 2:", count)
+	return count, nil
 }
 
-func Run() {
-	reports, err := shared.ReadFileByLineToSplitInts("days/day02/input.txt", " ")
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+func parse(r io.Reader) ([][]int, error) {
+	return shared.ReadByLineToSplitInts(r, " ")
+}
 
-	part1(reports)
-	part2(reports)
+func init() {
+	solver.Register(2, solver.New(parse, part1, part2))
 }
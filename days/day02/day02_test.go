@@ -0,0 +1,70 @@
+package day02
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `7 6 4 2 1
+1 2 7 8 9
+9 7 6 2 1
+1 3 2 4 5
+8 6 4 4 1
+1 3 6 7 9`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, [][]int) (int, error)
+		want int
+	}{
+		{name: "part 1", part: part1, want: 2},
+		{name: "part 2", part: part2, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestReportSafety(t *testing.T) {
+	tests := []struct {
+		report         []int
+		safe           bool
+		safeWithDamper bool
+	}{
+		{report: []int{7, 6, 4, 2, 1}, safe: true, safeWithDamper: true},
+		{report: []int{1, 2, 7, 8, 9}, safe: false, safeWithDamper: false},
+		{report: []int{9, 7, 6, 2, 1}, safe: false, safeWithDamper: false},
+		{report: []int{1, 3, 2, 4, 5}, safe: false, safeWithDamper: true},
+		{report: []int{8, 6, 4, 4, 1}, safe: false, safeWithDamper: true},
+		{report: []int{1, 3, 6, 7, 9}, safe: true, safeWithDamper: true},
+		{report: []int{9, 1, 2, 3, 4}, safe: false, safeWithDamper: true},
+		{report: []int{1, 2, 3, 4, 9}, safe: false, safeWithDamper: true},
+	}
+
+	for _, tt := range tests {
+		if got := isSafe(tt.report); got != tt.safe {
+			t.Errorf("isSafe(%v) = %v, want %v", tt.report, got, tt.safe)
+		}
+		if got := isSafeWithDampener(tt.report); got != tt.safeWithDamper {
+			t.Errorf("isSafeWithDampener(%v) = %v, want %v", tt.report, got, tt.safeWithDamper)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
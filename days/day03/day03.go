@@ -1,12 +1,12 @@
 package day03
 
 import (
-	"fmt"
-	"log"
+	"context"
 	"regexp"
 	"strconv"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 func strMul(xStr string, yStr string) (int, error) {
@@ -22,7 +22,7 @@ func strMul(xStr string, yStr string) (int, error) {
 	return x * y, nil
 }
 
-func part1(memory string) error {
+func part1(_ context.Context, memory string) (int, error) {
 	re := regexp.MustCompile(`mul\((\d{1,3}),\s*(\d{1,3})\)`)
 	matches := re.FindAllStringSubmatch(memory, -1)
 
@@ -33,16 +33,15 @@ func part1(memory string) error {
 
 		res, err := strMul(xStr, yStr)
 		if err != nil {
-			return err
+			return 0, err
 		}
 
 		tot += res
 	}
-	fmt.Println("Part 1:", tot)
-	return nil
+	return tot, nil
 }
 
-func part2(memory string) error {
+func part2(_ context.Context, memory string) (int, error) {
 	pattern := `(do|don't|mul)\((\d{1,3})?,?(\d{1,3})?\)`
 	regex := regexp.MustCompile(pattern)
 
@@ -69,33 +68,16 @@ func part2(memory string) error {
 
 			res, err := strMul(xStr, yStr)
 			if err != nil {
-				return err
+				return 0, err
 			}
 
 			tot += res
 
 		}
 	}
-	fmt.Println("Part 2:", tot)
-	return nil
+	return tot, nil
 }
 
-func Run() {
-	memory, err := shared.ReadFileToString("days/day03/input.txt")
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-
-	err = part1(memory)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-
-	err = part2(memory)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+func init() {
+	solver.Register(3, solver.New(shared.ReadToString, part1, part2))
 }
@@ -0,0 +1,57 @@
+package day03
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))"
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, string) (int, error)
+		want  int
+	}{
+		{
+			name:  "part 1",
+			input: "xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))",
+			part:  part1,
+			want:  161,
+		},
+		{
+			name:  "part 2",
+			input: example,
+			part:  part2,
+			want:  48,
+		},
+		{
+			name:  "part 2 ignores malformed instructions",
+			input: "mul(4*don't()mul(1,2)do()mul ( 2 , 4 )mul(1234,1)mul(3,3)",
+			part:  part2,
+			want:  9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, shared.ReadToString, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, shared.ReadToString, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadToString, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadToString, part2, example)
+}
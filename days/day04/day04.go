@@ -1,10 +1,10 @@
 package day04
 
 import (
-	"fmt"
-	"log"
+	"context"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 var letterIndex = map[int]rune{
@@ -137,7 +137,7 @@ func checkCandidatePart2(grid shared.Grid[rune], candidate []shared.Point) bool
 	return true
 }
 
-func part1(grid shared.Grid[rune]) {
+func part1(_ context.Context, grid shared.Grid[rune]) (int, error) {
 	tot := 0
 	for y, row := range grid.Rows() {
 		for x, letter := range row {
@@ -154,10 +154,10 @@ func part1(grid shared.Grid[rune]) {
 			}
 		}
 	}
-	fmt.Println("Part 1:", tot)
+	return tot, nil
 }
 
-func part2(grid shared.Grid[rune]) {
+func part2(_ context.Context, grid shared.Grid[rune]) (int, error) {
 	tot := 0
 	for y, row := range grid.Rows() {
 		for x, letter := range row {
@@ -172,16 +172,9 @@ func part2(grid shared.Grid[rune]) {
 			}
 		}
 	}
-	fmt.Println("Part 2:", tot)
+	return tot, nil
 }
 
-func Run() {
-	grid, err := shared.ReadFileToRuneGrid("days/day04/input.txt")
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-
-	part1(grid)
-	part2(grid)
+func init() {
+	solver.Register(4, solver.New(shared.ReadToRuneGrid, part1, part2))
 }
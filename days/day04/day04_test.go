@@ -0,0 +1,53 @@
+package day04
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `MMMSXXMASM
+MSAMXMSMSA
+AMXSXMAAMM
+MSAMASMSMX
+XMASAMXAMM
+XXAMMXXAMA
+SMSMSASXSS
+SAXAMASAAA
+MAMMMXMMMM
+MXMXAXMASX`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, shared.Grid[rune]) (int, error)
+		want  int
+	}{
+		{name: "part 1", input: example, part: part1, want: 18},
+		{name: "part 2", input: example, part: part2, want: 9},
+		{name: "part 1 single word", input: "..X...\n.SAMX.\n.A..A.\nXMAS.S\n.X....", part: part1, want: 4},
+		{name: "part 2 single cross", input: "M.S\n.A.\nM.S", part: part2, want: 1},
+		{name: "part 2 crossing same letters", input: "M.M\n.A.\nS.M", part: part2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, shared.ReadToRuneGrid, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, shared.ReadToRuneGrid, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadToRuneGrid, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadToRuneGrid, part2, example)
+}
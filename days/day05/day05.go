@@ -1,13 +1,14 @@
 package day05
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
 	"sort"
 	"strconv"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 type tuple struct {
@@ -32,6 +33,11 @@ func (s sorter) Swap(i, j int) {
 	s.values[i], s.values[j] = s.values[j], s.values[i]
 }
 
+type input struct {
+	rules          *shared.Set[tuple]
+	pageCollection [][]string
+}
+
 func parseInput(rawInput []string) (rules *shared.Set[tuple], pageCollection [][]string) {
 	rules = shared.NewSet[tuple]()
 
@@ -69,21 +75,14 @@ func calculateMiddleIndexTotal(pages [][]string) (int, error) {
 	return tot, nil
 }
 
-func part(pages [][]string, partNr int) error {
-	tot, err := calculateMiddleIndexTotal(pages)
-	if err != nil {
-		return err
-	}
-	fmt.Printf("Part %v: %v\n", partNr, tot)
-	return nil
-}
-
-func part1(pages [][]string) error {
-	return part(pages, 1)
+func part1(_ context.Context, in input) (int, error) {
+	correct, _ := sortPages(in.rules, in.pageCollection)
+	return calculateMiddleIndexTotal(correct)
 }
 
-func part2(pages [][]string) error {
-	return part(pages, 2)
+func part2(_ context.Context, in input) (int, error) {
+	_, incorrect := sortPages(in.rules, in.pageCollection)
+	return calculateMiddleIndexTotal(incorrect)
 }
 
 func sortPages(rules *shared.Set[tuple], pageCollection [][]string) (correct [][]string, incorrect [][]string) {
@@ -105,25 +104,16 @@ func sortPages(rules *shared.Set[tuple], pageCollection [][]string) (correct [][
 	return correct, incorrect
 }
 
-func Run() {
-	rawInput, err := shared.ReadFileByLine("days/day05/input.txt")
+func parse(r io.Reader) (input, error) {
+	rawInput, err := shared.ReadByLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
 	rules, pageCollection := parseInput(rawInput)
-	correct, incorrect := sortPages(rules, pageCollection)
-
-	err = part1(correct)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+	return input{rules: rules, pageCollection: pageCollection}, nil
+}
 
-	err = part2(incorrect)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+func init() {
+	solver.Register(5, solver.New(parse, part1, part2))
 }
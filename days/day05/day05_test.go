@@ -0,0 +1,91 @@
+package day05
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `47|53
+97|13
+97|61
+97|47
+75|29
+61|13
+75|53
+29|13
+97|29
+53|29
+61|53
+97|53
+61|29
+47|13
+75|47
+97|75
+47|61
+75|61
+47|29
+75|13
+53|13
+
+75,47,61,53,29
+97,61,53,29,13
+75,29,13
+75,97,47,61,53
+61,13,29
+97,13,75,29,47`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, input) (int, error)
+		want int
+	}{
+		{name: "part 1", part: part1, want: 143},
+		{name: "part 2", part: part2, want: 123},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestSortPages(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	correct, incorrect := sortPages(in.rules, in.pageCollection)
+
+	if len(correct) != 3 {
+		t.Errorf("got %d correctly ordered updates, want 3", len(correct))
+	}
+
+	wantIncorrect := [][]string{
+		{"97", "75", "47", "61", "53"},
+		{"61", "29", "13"},
+		{"97", "75", "47", "29", "13"},
+	}
+	if len(incorrect) != len(wantIncorrect) {
+		t.Fatalf("got %d incorrectly ordered updates, want %d", len(incorrect), len(wantIncorrect))
+	}
+	for i, want := range wantIncorrect {
+		if !shared.SlicesEqual(incorrect[i], want) {
+			t.Errorf("update %d sorted to %v, want %v", i, incorrect[i], want)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
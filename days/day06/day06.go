@@ -1,62 +1,69 @@
 package day06
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
 	"sync"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
+type input struct {
+	grid          shared.FlatGrid[rune]
+	startingPoint shared.Point
+}
+
 type state struct {
 	position  shared.Point
-	direction string
+	direction shared.Direction
 }
 
-func newState(position shared.Point, direction string) state {
+func newState(position shared.Point, direction shared.Direction) state {
 	return state{position, direction}
 }
 
-func (s state) clone() state {
-	return newState(s.position, s.direction)
+// newStateSet creates a set with room for every state on the grid.
+func newStateSet(grid shared.FlatGrid[rune]) *shared.IndexSet[state] {
+	directions := len(shared.AllDirections)
+	toIndex := func(s state) int { return grid.Index(s.position)*directions + int(s.direction) }
+	fromIndex := func(i int) state { return newState(grid.Point(i/directions), shared.Direction(i%directions)) }
+	return shared.NewIndexSet(grid.Width()*grid.Height()*directions, toIndex, fromIndex)
 }
 
-var directionOrder = map[string]string{
-	"up":    "right",
-	"right": "down",
-	"down":  "left",
-	"left":  "up",
-}
-
-func getRoute(startingPoint shared.Point, grid shared.Grid[rune]) (route *shared.Set[shared.Point], loop bool) {
-	currentState := newState(startingPoint, "up")
-	route = shared.NewSet(currentState.position)
-	seenStates := shared.NewSet[state]()
-	var nextPosition shared.Point
+// ctxCheckInterval is how many steps getRoute takes between checks of its context, which are too slow to
+// make on every step when thousands of routes are walked at once.
+const ctxCheckInterval = 1024
+
+func getRoute(
+	ctx context.Context,
+	startingPoint shared.Point,
+	grid shared.FlatGrid[rune],
+) (route *shared.IndexSet[shared.Point], loop bool, err error) {
+	currentState := newState(startingPoint, shared.North)
+	route = shared.NewPointSet(grid.Width(), grid.Height())
+	route.Add(currentState.position)
+	seenStates := newStateSet(grid)
+
+	for step := 1; ; step++ {
+		if step%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, false, err
+			}
+		}
 
-	for {
 		if seenStates.Contains(currentState) {
-			return route, true
-		}
-		seenStates.Add(currentState.clone())
-
-		switch currentState.direction {
-		case "up":
-			nextPosition = currentState.position.Up()
-		case "down":
-			nextPosition = currentState.position.Down()
-		case "left":
-			nextPosition = currentState.position.Left()
-		case "right":
-			nextPosition = currentState.position.Right()
+			return route, true, nil
 		}
+		seenStates.Add(currentState)
 
+		nextPosition := currentState.position.Move(currentState.direction, 1)
 		if !grid.Contains(nextPosition) {
-			return route, false
+			return route, false, nil
 		}
 
 		if grid.Get(nextPosition) == '#' {
-			currentState.direction = directionOrder[currentState.direction]
+			currentState.direction = currentState.direction.TurnRight()
 			continue
 		}
 
@@ -65,14 +72,24 @@ func getRoute(startingPoint shared.Point, grid shared.Grid[rune]) (route *shared
 	}
 }
 
-func part1(route *shared.Set[shared.Point]) {
-	fmt.Println("Part 1:", route.Size())
+func part1(ctx context.Context, in input) (int, error) {
+	route, _, err := getRoute(ctx, in.startingPoint, in.grid)
+	if err != nil {
+		return 0, err
+	}
+	return route.Size(), nil
 }
 
-func part2(startingPoint shared.Point, route *shared.Set[shared.Point], grid shared.Grid[rune]) {
+func part2(ctx context.Context, in input) (int, error) {
+	grid, startingPoint := in.grid, in.startingPoint
+	route, _, err := getRoute(ctx, startingPoint, grid)
+	if err != nil {
+		return 0, err
+	}
+
 	candidatePoints := make([]shared.Point, 0, route.Size())
 
-	for _, point := range route.Items() {
+	for point := range route.All() {
 		if grid.Get(point) != '#' {
 			candidatePoints = append(candidatePoints, point)
 		}
@@ -84,18 +101,22 @@ func part2(startingPoint shared.Point, route *shared.Set[shared.Point], grid sha
 	results := make(chan bool, candidateCount)
 
 	var wg sync.WaitGroup
-	wg.Add(candidateCount)
-
 	for _, point := range candidatePoints {
-		go func(p shared.Point) {
-			defer wg.Done()
+		if ctx.Err() != nil {
+			break
+		}
+
+		clonedGrid := grid.Clone()
+		clonedGrid.Set(point, '#')
 
-			clonedGrid := grid.Clone()
-			clonedGrid.Set(p, '#')
-			_, loop := getRoute(startingPoint, clonedGrid)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 
-			results <- loop
-		}(point)
+			// The walk checks ctx as it goes, so the workers stop soon after a timeout.
+			_, loop, err := getRoute(ctx, startingPoint, clonedGrid)
+			results <- err == nil && loop
+		}()
 	}
 
 	go func() {
@@ -109,18 +130,25 @@ func part2(startingPoint shared.Point, route *shared.Set[shared.Point], grid sha
 		}
 	}
 
-	fmt.Println("Part 2:", tot)
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return tot, nil
 }
 
-func Run() {
-	grid, startingPoint, err := shared.ReadFileToRuneGridWithStartingPoint("days/day06/input.txt", '^')
+func parse(r io.Reader) (input, error) {
+	grid, startingPoint, err := shared.ReadToRuneGridWithStartingPoint(r, '^')
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
-	route, _ := getRoute(startingPoint, grid)
+	flat, err := shared.NewFlatGridFrom(grid)
+	if err != nil {
+		return input{}, err
+	}
+	return input{grid: flat, startingPoint: startingPoint}, nil
+}
 
-	part1(route)
-	part2(startingPoint, route, grid)
+func init() {
+	solver.Register(6, solver.New(parse, part1, part2))
 }
@@ -0,0 +1,76 @@
+package day06
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `....#.....
+.........#
+..........
+..#.......
+.......#..
+..........
+.#..^.....
+........#.
+#.........
+......#...`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, input) (int, error)
+		want int
+	}{
+		{name: "part 1", part: part1, want: 41},
+		{name: "part 2", part: part2, want: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestGetRouteDetectsLoop(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	if _, loop, err := getRoute(context.Background(), in.startingPoint, in.grid); err != nil || loop {
+		t.Errorf("expected the guard to leave the lab, got loop %t, error %v", loop, err)
+	}
+
+	grid := in.grid.Clone()
+	grid.Set(in.startingPoint.Left(), '#')
+	if _, loop, err := getRoute(context.Background(), in.startingPoint, grid); err != nil || !loop {
+		t.Errorf("expected the guard to get stuck in a loop, got loop %t, error %v", loop, err)
+	}
+}
+
+func TestGetRouteStopsWhenCancelled(t *testing.T) {
+	// An empty lab lets the guard walk far enough to check the context.
+	grid := shared.NewFlatGrid(3, 2*ctxCheckInterval, '.')
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := getRoute(ctx, shared.NewPoint(1, grid.Height()-1), grid)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
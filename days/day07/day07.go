@@ -1,12 +1,15 @@
 package day07
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"io"
 	"strconv"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver"
 )
 
 type testCase struct {
@@ -45,29 +48,13 @@ func parseLine(line string) (testCase, error) {
 	return newTestCase(testValue, components), nil
 }
 
+// reverseConcat undoes a concatenation: if the digits of a end with those of b it returns the digits before them.
 func reverseConcat(a, b int) (int, bool) {
-	numDigits := 0
-	tempB := b
-	for tempB > 0 {
-		numDigits++
-		tempB /= 10
-	}
-
-	aLastDigits := a % pow(10, numDigits)
-	if aLastDigits != b {
+	high, low := mathx.SplitDigits(a, mathx.DigitCount(b))
+	if low != b {
 		return 0, false
 	}
-
-	return a / pow(10, numDigits), true
-}
-
-func pow(base int, exp int) int {
-	result := 1
-	for exp > 0 {
-		result *= base
-		exp--
-	}
-	return result
+	return high, true
 }
 
 func evaluateTestCase(tc testCase, conc bool) bool {
@@ -113,46 +100,41 @@ func parseTestCases(lines []string) ([]testCase, error) {
 	return testCases, nil
 }
 
-func part1(testCases []testCase) (int, []testCase) {
-	var (
-		failedCases []testCase
-		tot         int
-	)
-
+func calibrationTotal(ctx context.Context, testCases []testCase, conc bool) (int, error) {
+	tot := 0
 	for _, tc := range testCases {
-		if evaluateTestCase(tc, false) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if evaluateTestCase(tc, false) || (conc && evaluateTestCase(tc, true)) {
 			tot += tc.testValue
-		} else {
-			failedCases = append(failedCases, tc)
 		}
 	}
+	return tot, nil
+}
 
-	fmt.Println("Part 1:", tot)
-	return tot, failedCases
+func part1(ctx context.Context, testCases []testCase) (int, error) {
+	return calibrationTotal(ctx, testCases, false)
 }
 
-func part2(subtotal int, testCases []testCase) {
-	for _, tc := range testCases {
-		if evaluateTestCase(tc, true) {
-			subtotal += tc.testValue
-		}
-	}
-	fmt.Println("Part 2:", subtotal)
+func part2(ctx context.Context, testCases []testCase) (int, error) {
+	return calibrationTotal(ctx, testCases, true)
 }
 
-func Run() {
-	lines, err := shared.ReadFileByLine("days/day07/input.txt")
+func parse(r io.Reader) ([]testCase, error) {
+	lines, err := shared.ReadByLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return nil, err
 	}
 
 	testCases, err := parseTestCases(lines)
 	if err != nil {
-		log.Fatalf("Error parsing test cases: %v", err)
-		return
+		return nil, fmt.Errorf("parsing test cases: %w", err)
 	}
+	return testCases, nil
+}
 
-	subTotal, failedCases := part1(testCases)
-	part2(subTotal, failedCases)
+func init() {
+	solver.Register(7, solver.New(parse, part1, part2))
 }
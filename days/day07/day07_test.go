@@ -0,0 +1,140 @@
+package day07
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `190: 10 19
+3267: 81 40 27
+83: 17 5
+156: 15 6
+7290: 6 8 6 15
+161011: 16 10 13
+192: 17 8 14
+21037: 9 7 18 13
+292: 11 6 16 20`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, []testCase) (int, error)
+		want int
+	}{
+		{name: "part 1", part: part1, want: 3749},
+		{name: "part 2", part: part2, want: 11387},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestReverseConcat(t *testing.T) {
+	tests := []struct {
+		a      int
+		b      int
+		want   int
+		wantOk bool
+	}{
+		{a: 156, b: 6, want: 15, wantOk: true},
+		{a: 1234, b: 34, want: 12, wantOk: true},
+		{a: 486, b: 486, want: 0, wantOk: true},
+		{a: 1010, b: 10, want: 10, wantOk: true},
+		{a: 123, b: 4, wantOk: false},
+		{a: 12, b: 312, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := reverseConcat(tt.a, tt.b)
+		if ok != tt.wantOk || got != tt.want {
+			t.Errorf("reverseConcat(%d, %d) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestEvaluateTestCase(t *testing.T) {
+	tests := []struct {
+		line string
+		conc bool
+		want bool
+	}{
+		{line: "190: 10 19", conc: false, want: true},
+		{line: "83: 17 5", conc: false, want: false},
+		{line: "156: 15 6", conc: false, want: false},
+		{line: "156: 15 6", conc: true, want: true},
+		{line: "7290: 6 8 6 15", conc: true, want: true},
+		{line: "192: 17 8 14", conc: true, want: true},
+		{line: "21037: 9 7 18 13", conc: true, want: false},
+	}
+
+	for _, tt := range tests {
+		tc, err := parseLine(tt.line)
+		if err != nil {
+			t.Fatalf("parsing %q: %v", tt.line, err)
+		}
+		if got := evaluateTestCase(tc, tt.conc); got != tt.want {
+			t.Errorf("evaluateTestCase(%q, %v) = %v, want %v", tt.line, tt.conc, got, tt.want)
+		}
+	}
+}
+
+type operator func(a, b int) int
+
+func add(a, b int) int { return a + b }
+
+func multiply(a, b int) int { return a * b }
+
+func concat(a, b int) int {
+	n, _ := strconv.Atoi(strconv.Itoa(a) + strconv.Itoa(b))
+	return n
+}
+
+// solvable tries every assignment of operators from left to right, the way the puzzle describes it.
+func solvable(tc testCase, operators []operator) bool {
+	for ops := range shared.ProductRepeat(operators, len(tc.components)-1) {
+		value := tc.components[0]
+		for i, op := range ops {
+			value = op(value, tc.components[i+1])
+		}
+		if value == tc.testValue {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEvaluateTestCaseMatchesEveryAssignment(t *testing.T) {
+	testCases, err := parseTestCases(strings.Split(example, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range testCases {
+		if got, want := evaluateTestCase(tc, false), solvable(tc, []operator{add, multiply}); got != want {
+			t.Errorf("evaluateTestCase(%v, false) = %v, want %v", tc, got, want)
+		}
+		if got, want := evaluateTestCase(tc, true), solvable(tc, []operator{add, multiply, concat}); got != want {
+			t.Errorf("evaluateTestCase(%v, true) = %v, want %v", tc, got, want)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
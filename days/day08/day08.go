@@ -1,12 +1,18 @@
 package day08
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
+type input struct {
+	grid  shared.Grid[rune]
+	pairs []antennaPair
+}
+
 type antennaPair struct {
 	a shared.Point
 	b shared.Point
@@ -85,42 +91,43 @@ func findAntennas(grid shared.Grid[rune]) map[rune][]shared.Point {
 	return antennas
 }
 
-func part1(grid shared.Grid[rune], pairs []antennaPair) {
+func part1(_ context.Context, in input) (int, error) {
 	antinodeLocations := shared.NewSet[shared.Point]()
 
-	for _, pair := range pairs {
-		antinodes := pair.antinodes(grid)
+	for _, pair := range in.pairs {
+		antinodes := pair.antinodes(in.grid)
 		for _, antinode := range antinodes {
 			antinodeLocations.Add(antinode)
 		}
 	}
 
-	fmt.Println("Part 1:", antinodeLocations.Size())
+	return antinodeLocations.Size(), nil
 }
 
-func part2(grid shared.Grid[rune], pairs []antennaPair) {
+func part2(_ context.Context, in input) (int, error) {
 	antinodeLocations := shared.NewSet[shared.Point]()
 
-	for _, pair := range pairs {
-		antinodes := pair.antinodesWithResonantHarmonics(grid)
+	for _, pair := range in.pairs {
+		antinodes := pair.antinodesWithResonantHarmonics(in.grid)
 		for _, antinode := range antinodes {
 			antinodeLocations.Add(antinode)
 		}
 	}
 
-	fmt.Println("Part 2:", antinodeLocations.Size())
+	return antinodeLocations.Size(), nil
 }
 
-func Run() {
-	grid, err := shared.ReadFileToRuneGrid("days/day08/input.txt")
+func parse(r io.Reader) (input, error) {
+	grid, err := shared.ReadToRuneGrid(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
 	antennas := findAntennas(grid)
 	pairs := getAntennaPairs(antennas)
+	return input{grid: grid, pairs: pairs}, nil
+}
 
-	part1(grid, pairs)
-	part2(grid, pairs)
+func init() {
+	solver.Register(8, solver.New(parse, part1, part2))
 }
@@ -0,0 +1,63 @@
+package day08
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `............
+........0...
+.....0......
+.......0....
+....0.......
+......A.....
+............
+............
+........A...
+.........A..
+............
+............`
+
+const harmonicsExample = `T.........
+...T......
+.T........
+..........
+..........
+..........
+..........
+..........
+..........
+..........`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, input) (int, error)
+		want  int
+	}{
+		{name: "part 1", input: example, part: part1, want: 14},
+		{name: "part 2", input: example, part: part2, want: 34},
+		{name: "part 2 resonant harmonics", input: harmonicsExample, part: part2, want: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
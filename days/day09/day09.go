@@ -1,10 +1,12 @@
 package day09
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
+	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 const empty = -1
@@ -100,10 +102,14 @@ func parseFragMemory(memory []int) ([]program, []freeMemory) {
 	return programs, free
 }
 
-func sortFragMemory(programs []program, free []freeMemory) []program {
+func sortFragMemory(ctx context.Context, programs []program, free []freeMemory) ([]program, error) {
 	lastProgramID := programs[len(programs)-1].id
 
 	for i := lastProgramID; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		for j := range free {
 			freeBlock := &free[j]
 
@@ -123,10 +129,10 @@ func sortFragMemory(programs []program, free []freeMemory) []program {
 		}
 	}
 
-	return programs
+	return programs, nil
 }
 
-func part1(memory []int) {
+func part1(_ context.Context, memory []int) (int, error) {
 	unwrappedMemory := unwrapMemory(memory)
 	sortedMemory := sortMemory(unwrappedMemory)
 
@@ -137,12 +143,15 @@ func part1(memory []int) {
 		}
 		tot += i * value
 	}
-	fmt.Println("Part 1:", tot)
+	return tot, nil
 }
 
-func part2(memory []int) {
+func part2(ctx context.Context, memory []int) (int, error) {
 	programs, free := parseFragMemory(memory)
-	defraggedPrograms := sortFragMemory(programs, free)
+	defraggedPrograms, err := sortFragMemory(ctx, programs, free)
+	if err != nil {
+		return 0, err
+	}
 
 	tot := 0
 	for _, p := range defraggedPrograms {
@@ -151,18 +160,18 @@ func part2(memory []int) {
 		}
 	}
 
-	fmt.Println("Part 2:", tot)
+	return tot, nil
 }
 
-func Run() {
-	rawInput, err := shared.ReadFileToString("days/day09/input.txt")
+func parse(r io.Reader) ([]int, error) {
+	rawInput, err := shared.ReadToString(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return nil, err
 	}
 
-	memory := parseInput(rawInput)
+	return parseInput(strings.TrimSpace(rawInput)), nil
+}
 
-	part1(memory)
-	part2(memory)
+func init() {
+	solver.Register(9, solver.New(parse, part1, part2))
 }
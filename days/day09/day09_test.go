@@ -0,0 +1,49 @@
+package day09
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = "2333133121414131402\n"
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, []int) (int, error)
+		want int
+	}{
+		{name: "part 1", part: part1, want: 1928},
+		{name: "part 2", part: part2, want: 2858},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestSortMemory(t *testing.T) {
+	memory := unwrapMemory(parseInput("12345"))
+	want := []int{0, 2, 2, 1, 1, 1, 2, 2, 2, empty, empty, empty, empty, empty, empty}
+
+	if got := sortMemory(memory); !shared.SlicesEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
@@ -1,37 +1,37 @@
 package day10
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
+type input struct {
+	grid       shared.FlatGrid[int]
+	trailheads []shared.Point
+}
+
 const top = 9
 
-func findTrailheads(grid shared.Grid[int]) []shared.Point {
+func findTrailheads(grid shared.FlatGrid[int]) []shared.Point {
 	var trailheads []shared.Point
 
-	for y, row := range grid.Rows() {
-		for x, height := range row {
-			if height == 0 {
-				trailheads = append(trailheads, shared.NewPoint(x, y))
-			}
+	for p, height := range grid.Cells() {
+		if height == 0 {
+			trailheads = append(trailheads, p)
 		}
 	}
 
 	return trailheads
 }
 
-func possibleTrails(grid shared.Grid[int], trailhead shared.Point) []shared.Point {
+func possibleTrails(grid shared.FlatGrid[int], trailhead shared.Point) []shared.Point {
 	var reachableTrailheads []shared.Point
 	currentHeight := grid.Get(trailhead)
 
-	for _, neighbor := range trailhead.CardinalNeighbors() {
-		if !grid.Contains(neighbor) {
-			continue
-		}
-
+	for neighbor := range grid.Neighbors(trailhead) {
 		neighborHeight := grid.Get(neighbor)
 		if neighborHeight == currentHeight+1 {
 			if neighborHeight == top {
@@ -45,32 +45,36 @@ func possibleTrails(grid shared.Grid[int], trailhead shared.Point) []shared.Poin
 	return reachableTrailheads
 }
 
-func part1(trailheads []shared.Point, grid shared.Grid[int]) {
+func part1(_ context.Context, in input) (int, error) {
 	tot := 0
-	for _, trailhead := range trailheads {
-		trails := possibleTrails(grid, trailhead)
+	for _, trailhead := range in.trailheads {
+		trails := possibleTrails(in.grid, trailhead)
 		tot += len(shared.UniqueSlice(trails))
 	}
-	fmt.Println("Part 1:", tot)
+	return tot, nil
 }
 
-func part2(trailheads []shared.Point, grid shared.Grid[int]) {
+func part2(_ context.Context, in input) (int, error) {
 	tot := 0
-	for _, trailhead := range trailheads {
-		tot += len(possibleTrails(grid, trailhead))
+	for _, trailhead := range in.trailheads {
+		tot += len(possibleTrails(in.grid, trailhead))
 	}
-	fmt.Println("Part 2:", tot)
+	return tot, nil
 }
 
-func Run() {
-	grid, err := shared.ReadFileToIntGrid("days/day10/input.txt")
+func parse(r io.Reader) (input, error) {
+	grid, err := shared.ReadToIntGrid(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
-	trailheads := findTrailheads(grid)
+	flat, err := shared.NewFlatGridFrom(grid)
+	if err != nil {
+		return input{}, err
+	}
+	return input{grid: flat, trailheads: findTrailheads(flat)}, nil
+}
 
-	part1(trailheads, grid)
-	part2(trailheads, grid)
+func init() {
+	solver.Register(10, solver.New(parse, part1, part2))
 }
@@ -0,0 +1,48 @@
+package day10
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `89010123
+78121874
+87430965
+96549874
+45678903
+32019012
+01329801
+10456732`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, input) (int, error)
+		want  int
+	}{
+		{name: "part 1", input: example, part: part1, want: 36},
+		{name: "part 2", input: example, part: part2, want: 81},
+		{name: "part 1 single trail", input: "0123\n1234\n8765\n9876", part: part1, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
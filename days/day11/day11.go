@@ -1,56 +1,48 @@
 package day11
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"math/big"
 
 	"aoc2024/shared"
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver"
 )
 
-func countDigits(number int) int {
-	count := 0
-	for number != 0 {
-		number /= 10
-		count++
-	}
-	return count
-}
+var errStoneTooLarge = errors.New("stone number does not fit in an int")
 
-func splitNumber(n int, numDigits int) (int, int) {
-	halfLength := numDigits / 2
-	divisor := 1
-	for range halfLength {
-		divisor *= 10
+func blink(num int) ([]int, error) {
+	if num == 0 {
+		return []int{1}, nil
 	}
-	firstHalf := n / divisor
-	secondHalf := n % divisor
-	return firstHalf, secondHalf
-}
 
-func blink(num int, cache map[int][]int) []int {
-	if cachedReturnValue, ok := cache[num]; ok {
-		return cachedReturnValue
+	digitCount := mathx.DigitCount(num)
+	if digitCount%2 == 0 {
+		first, second := mathx.SplitDigits(num, digitCount/2)
+		return []int{first, second}, nil
 	}
 
-	var returnValue []int
-
-	if num == 0 {
-		returnValue = []int{1}
-		cache[num] = returnValue
-		return returnValue
+	next, ok := mathx.Mul(num, 2024)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d * 2024", errStoneTooLarge, num)
 	}
+	return []int{next}, nil
+}
 
-	digitCount := countDigits(num)
-	if digitCount%2 == 0 {
-		first, second := splitNumber(num, digitCount)
-		returnValue = []int{first, second}
-		cache[num] = returnValue
-		return returnValue
-	}
+// blinked is what one stone turns into when blinking, kept together with the error so it can be memoized.
+type blinked struct {
+	stones []int
+	err    error
+}
 
-	returnValue = []int{num * 2024}
-	cache[num] = returnValue
-	return returnValue
+func newBlinkMemo() *shared.Memo[int, blinked] {
+	return shared.NewMemo(func(num int, _ func(int) blinked) blinked {
+		stones, err := blink(num)
+		return blinked{stones: stones, err: err}
+	})
 }
 
 func parseCounts(stones []int) map[int]int {
@@ -61,54 +53,69 @@ func parseCounts(stones []int) map[int]int {
 	return counts
 }
 
-func nextCounts(currCounts map[int]int, cache map[int][]int) map[int]int {
-	newCounts := make(map[int]int)
+// nextCounts blinks once. The counts are Numbers because they grow exponentially with the blinks.
+func nextCounts(currCounts map[int]mathx.Number, blinks *shared.Memo[int, blinked]) (map[int]mathx.Number, error) {
+	newCounts := make(map[int]mathx.Number, len(currCounts))
 
-	for num := range currCounts {
-		newStones := blink(num, cache)
-		for _, newStone := range newStones {
-			newCounts[newStone] += currCounts[num]
+	for num, count := range currCounts {
+		next := blinks.Get(num)
+		if next.err != nil {
+			return nil, next.err
+		}
+		for _, newStone := range next.stones {
+			newCounts[newStone] = newCounts[newStone].Add(count)
 		}
 	}
-	return newCounts
+	return newCounts, nil
 }
 
-func part1(stoneCounts map[int]int, cache map[int][]int) map[int]int {
-	for range 25 {
-		stoneCounts = nextCounts(stoneCounts, cache)
+func countStones(ctx context.Context, initialCounts map[int]int, blinks int) (mathx.Number, error) {
+	stoneCounts := make(map[int]mathx.Number, len(initialCounts))
+	for stone, count := range initialCounts {
+		stoneCounts[stone] = mathx.NewNumber(count)
 	}
 
-	tot := 0
-	for _, count := range stoneCounts {
-		tot += count
-	}
-	fmt.Println("Part 1:", tot)
-
-	return stoneCounts
-}
+	memo := newBlinkMemo()
+	for range blinks {
+		if err := ctx.Err(); err != nil {
+			return mathx.Number{}, err
+		}
 
-func part2(stoneCounts map[int]int, cache map[int][]int) {
-	for range 50 {
-		stoneCounts = nextCounts(stoneCounts, cache)
+		var err error
+		stoneCounts, err = nextCounts(stoneCounts, memo)
+		if err != nil {
+			return mathx.Number{}, err
+		}
 	}
+	shared.ReportMemo(ctx, fmt.Sprintf("blink (%d blinks)", blinks), memo.Stats())
 
-	tot := 0
+	var tot mathx.Number
 	for _, count := range stoneCounts {
-		tot += count
+		tot = tot.Add(count)
 	}
-	fmt.Println("Part 2:", tot)
+	return tot, nil
 }
 
-func Run() {
-	stones, err := shared.ReadFileBySingleIntLine("days/day11/input.txt", " ")
+func part1(ctx context.Context, stoneCounts map[int]int) (*big.Int, error) {
+	tot, err := countStones(ctx, stoneCounts, 25)
+	return tot.BigInt(), err
+}
+
+// part2 blinks 75 times, or as often as the blinks parameter says.
+func part2(ctx context.Context, stoneCounts map[int]int) (*big.Int, error) {
+	tot, err := countStones(ctx, stoneCounts, solver.IntParam(ctx, "blinks", 75))
+	return tot.BigInt(), err
+}
+
+func parse(r io.Reader) (map[int]int, error) {
+	stones, err := shared.ReadBySingleIntLine(r, " ")
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return nil, err
 	}
 
-	stoneCounts := parseCounts(stones)
-	cache := make(map[int][]int)
+	return parseCounts(stones), nil
+}
 
-	stoneCounts = part1(stoneCounts, cache)
-	part2(stoneCounts, cache)
+func init() {
+	solver.Register(11, solver.New(parse, part1, part2))
 }
@@ -0,0 +1,159 @@
+package day11
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = "125 17"
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, map[int]int) (*big.Int, error)
+		want *big.Int
+	}{
+		{name: "part 1", part: part1, want: big.NewInt(55312)},
+		{name: "part 2", part: part2, want: big.NewInt(65601038650482)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestCountStones(t *testing.T) {
+	tests := []struct {
+		stones []int
+		blinks int
+		want   int
+	}{
+		{stones: []int{0, 1, 10, 99, 999}, blinks: 1, want: 7},
+		{stones: []int{125, 17}, blinks: 1, want: 3},
+		{stones: []int{125, 17}, blinks: 6, want: 22},
+	}
+
+	for _, tt := range tests {
+		got, err := countStones(context.Background(), parseCounts(tt.stones), tt.blinks)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Cmp(mathx.NewNumber(tt.want)) != 0 {
+			t.Errorf("countStones(%v, %d) = %v, want %d", tt.stones, tt.blinks, got, tt.want)
+		}
+	}
+}
+
+func TestPart2BlinksParam(t *testing.T) {
+	stones := solvertest.Parse(t, parse, example)
+
+	got, err := part2(solver.WithParam(context.Background(), "blinks", 6), stones)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Int64() != 22 {
+		t.Errorf("got %v, want 22", got)
+	}
+}
+
+func TestCountStonesBeyondInt(t *testing.T) {
+	// The count roughly grows by half every blink, so 160 blinks is far past what an int can hold.
+	got, err := countStones(context.Background(), parseCounts([]int{125, 17}), 160)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.IsBig() {
+		t.Fatalf("countStones after 160 blinks = %v, which should not fit in an int", got)
+	}
+
+	// Each blink only depends on the previous counts, so 160 blinks of a stone equal 80 blinks of
+	// every stone it turns into after 80, which still fit in an int.
+	counts := parseCounts([]int{125, 17})
+	memo := newBlinkMemo()
+	for range 80 {
+		next := make(map[int]int)
+		for stone, count := range counts {
+			for _, s := range memo.Get(stone).stones {
+				next[s] += count
+			}
+		}
+		counts = next
+	}
+
+	want := mathx.NewNumber(0)
+	for stone, count := range counts {
+		n, err := countStones(context.Background(), map[int]int{stone: 1}, 80)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want = want.Add(n.Mul(mathx.NewNumber(count)))
+	}
+	if got.Cmp(want) != 0 {
+		t.Errorf("countStones after 160 blinks = %v, want %v", got, want)
+	}
+}
+
+func TestBlink(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{n: 0, want: []int{1}},
+		{n: 1, want: []int{2024}},
+		{n: 10, want: []int{1, 0}},
+		{n: 2024, want: []int{20, 24}},
+		{n: 1000, want: []int{10, 0}},
+	}
+
+	for _, tt := range tests {
+		got, err := blink(tt.n)
+		if err != nil {
+			t.Fatalf("blink(%d): unexpected error: %v", tt.n, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("blink(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLargeStoneOverflows(t *testing.T) {
+	// The stone has an odd number of digits, so it is multiplied by 2024, which no longer fits in an int.
+	const stone = 10000000000000000
+
+	if _, err := blink(stone); !errors.Is(err, errStoneTooLarge) {
+		t.Errorf("blink(%d) error = %v, want %v", stone, err, errStoneTooLarge)
+	}
+	if _, err := countStones(context.Background(), parseCounts([]int{stone}), 2); !errors.Is(err, errStoneTooLarge) {
+		t.Errorf("countStones(%d, 2) error = %v, want %v", stone, err, errStoneTooLarge)
+	}
+
+	// Splitting the stone first keeps the numbers small.
+	got, err := countStones(context.Background(), parseCounts([]int{stone * 10}), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Cmp(mathx.NewNumber(2)) != 0 {
+		t.Errorf("countStones(%d, 2) = %v, want 2", stone*10, got)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
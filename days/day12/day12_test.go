@@ -0,0 +1,111 @@
+package day12
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const (
+	smallExample = `AAAA
+BBCD
+BBCC
+EEEC`
+
+	enclosedExample = `OOOOO
+OXOXO
+OOOOO
+OXOXO
+OOOOO`
+
+	eShapeExample = `EEEEE
+EXXXX
+EEEEE
+EXXXX
+EEEEE`
+
+	diagonalExample = `AAAAAA
+AAABBA
+AAABBA
+ABBAAA
+ABBAAA
+AAAAAA`
+
+	largeExample = `RRRRIICCFF
+RRRRIICCCF
+VVRRRCCFFF
+VVRCCCJFFF
+VVVVCJJCFE
+VVIVCCJJEE
+VVIIICJJEE
+MIIIIIJJEE
+MIIISIJEEE
+MMMISSJEEE`
+)
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, shared.FlatGrid[rune]) (int, error)
+		want  int
+	}{
+		{name: "part 1 small", input: smallExample, part: part1, want: 140},
+		{name: "part 1 enclosed", input: enclosedExample, part: part1, want: 772},
+		{name: "part 1 large", input: largeExample, part: part1, want: 1930},
+		{name: "part 2 small", input: smallExample, part: part2, want: 80},
+		{name: "part 2 enclosed", input: enclosedExample, part: part2, want: 436},
+		{name: "part 2 e-shape", input: eShapeExample, part: part2, want: 236},
+		{name: "part 2 diagonal", input: diagonalExample, part: part2, want: 368},
+		{name: "part 2 large", input: largeExample, part: part2, want: 1206},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func TestParseRaggedRows(t *testing.T) {
+	if _, err := parse(strings.NewReader("AAA\nAB\nAAA")); err == nil {
+		t.Errorf("expected an error for a row that is too short")
+	}
+}
+
+func TestFindRegions(t *testing.T) {
+	grid := solvertest.Parse(t, parse, smallExample)
+
+	regions := findRegions(grid)
+	if len(regions) != 5 {
+		t.Fatalf("got %d regions, want 5", len(regions))
+	}
+
+	sizes := make(map[rune]int)
+	for _, region := range regions {
+		plot, _ := region.Peek()
+		sizes[grid.Get(plot)] = region.Size()
+	}
+
+	want := map[rune]int{'A': 4, 'B': 4, 'C': 4, 'D': 1, 'E': 3}
+	for plant, size := range want {
+		if sizes[plant] != size {
+			t.Errorf("region %c has %d plots, want %d", plant, sizes[plant], size)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, largeExample)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, largeExample)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, largeExample)
+}
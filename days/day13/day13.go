@@ -1,12 +1,14 @@
 package day13
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"io"
 	"regexp"
 	"strconv"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 const ConversionError = 10_000_000_000_000
@@ -107,7 +109,7 @@ func findMachineCost(m machine, errorCorrection int) (int, error) {
 	return 3*a + b, nil
 }
 
-func part1(machines []machine) {
+func part1(_ context.Context, machines []machine) (int, error) {
 	tot := 0
 	for _, m := range machines {
 		cost, err := findMachineCost(m, 0)
@@ -116,10 +118,10 @@ func part1(machines []machine) {
 		}
 		tot += cost
 	}
-	fmt.Println("Part 1:", tot)
+	return tot, nil
 }
 
-func part2(machines []machine) {
+func part2(_ context.Context, machines []machine) (int, error) {
 	tot := 0
 	for _, m := range machines {
 		cost, err := findMachineCost(m, ConversionError)
@@ -128,22 +130,18 @@ func part2(machines []machine) {
 		}
 		tot += cost
 	}
-	fmt.Println("Part 2:", tot)
+	return tot, nil
 }
 
-func Run() {
-	rawInput, err := shared.ReadFileByBlankLine("days/day13/input.txt")
+func parse(r io.Reader) ([]machine, error) {
+	rawInput, err := shared.ReadByBlankLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return nil, err
 	}
 
-	machines, err := parseInput(rawInput)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+	return parseInput(rawInput)
+}
 
-	part1(machines)
-	part2(machines)
+func init() {
+	solver.Register(13, solver.New(parse, part1, part2))
 }
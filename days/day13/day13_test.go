@@ -0,0 +1,88 @@
+package day13
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `Button A: X+94, Y+34
+Button B: X+22, Y+67
+Prize: X=8400, Y=5400
+
+Button A: X+26, Y+66
+Button B: X+67, Y+21
+Prize: X=12748, Y=12176
+
+Button A: X+17, Y+86
+Button B: X+84, Y+37
+Prize: X=7870, Y=6450
+
+Button A: X+69, Y+23
+Button B: X+27, Y+71
+Prize: X=18641, Y=10279`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, []machine) (int, error)
+		want int
+	}{
+		{name: "part 1", part: part1, want: 480},
+		{name: "part 2", part: part2, want: 875318608908},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestSolveLinearSystem(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        vector
+		b        vector
+		solution vector
+		wantA    int
+		wantB    int
+		wantErr  bool
+	}{
+		{name: "integer solution", a: newVector(94, 34), b: newVector(22, 67), solution: newVector(8400, 5400), wantA: 80, wantB: 40},
+		{name: "second integer solution", a: newVector(17, 86), b: newVector(84, 37), solution: newVector(7870, 6450), wantA: 38, wantB: 86},
+		{name: "non-integer solution", a: newVector(26, 66), b: newVector(67, 21), solution: newVector(12748, 12176), wantErr: true},
+		{name: "parallel buttons", a: newVector(1, 2), b: newVector(2, 4), solution: newVector(3, 6), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b, err := solveLinearSystem(tt.a, tt.b, tt.solution)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %d, %d", a, b)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if a != tt.wantA || b != tt.wantB {
+				t.Errorf("got %d, %d, want %d, %d", a, b, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
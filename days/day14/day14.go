@@ -1,17 +1,25 @@
 package day14
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"image/color"
+	"io"
+	"math"
 	"regexp"
 	"strconv"
 
 	"aoc2024/shared"
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/render"
+	"aoc2024/shared/solver"
 )
 
 const (
 	roomWidth  = 101
 	roomHeight = 103
+	seconds    = 100
 )
 
 type roomSize struct {
@@ -42,15 +50,8 @@ func newRobot(position shared.Point, velocity velocity) robot {
 }
 
 func (r robot) positionAtTime(t int, rs roomSize) shared.Point {
-	newX := (r.position.X + r.velocity.x*t) % rs.width
-	if newX < 0 {
-		newX += rs.width
-	}
-	newY := (r.position.Y + r.velocity.y*t) % rs.height
-	if newY < 0 {
-		newY += rs.height
-	}
-	return shared.NewPoint(newX, newY)
+	moved := shared.NewPoint(r.position.X+r.velocity.x*t, r.position.Y+r.velocity.y*t)
+	return moved.Wrap(rs.width, rs.height)
 }
 
 func (r robot) quadrantAtTime(t int, rs roomSize) int {
@@ -104,10 +105,10 @@ func parseInput(input []string) ([]robot, error) {
 	return robots, nil
 }
 
-func part1(robots []robot, rs roomSize) {
+func safetyFactor(robots []robot, rs roomSize, t int) int {
 	quadrants := make([]int, 4)
 	for _, bot := range robots {
-		quadrant := bot.quadrantAtTime(100, rs)
+		quadrant := bot.quadrantAtTime(t, rs)
 		if quadrant == 0 {
 			continue
 		}
@@ -119,51 +120,118 @@ func part1(robots []robot, rs roomSize) {
 		mul *= quad
 	}
 
-	fmt.Println("Part 1:", mul)
+	return mul
+}
+
+func part1(_ context.Context, robots []robot) (int, error) {
+	return safetyFactor(robots, newRoomSize(roomWidth, roomHeight), seconds), nil
 }
 
-func part2(robots []robot, rs roomSize) {
-	t := 0
-	for {
-		grid := shared.NewEmptyGrid(rs.width, rs.height, '.')
+// spread measures how far apart the values are: their variance scaled by the count squared, which is
+// smallest when the robots bunch together.
+func spread(values []int) int {
+	sum, sumOfSquares := 0, 0
+	for _, v := range values {
+		sum += v
+		sumOfSquares += v * v
+	}
+	return len(values)*sumOfSquares - sum*sum
+}
 
-		for _, bot := range robots {
-			pos := bot.positionAtTime(t, rs)
-			grid.Set(pos, '#')
+// The tree is drawn by hundreds of robots. With only a few, some frame is bound to look bunched up by
+// chance, as is the example whose robots all start in a small corner of the room.
+const minTreeRobots = 100
+
+var errNoTree = errors.New("the robots never cluster into a picture")
+
+// clusterTime returns the time within one period of a coordinate at which that coordinate is most bunched up.
+// Every robot's coordinate repeats after period steps, so no later time can be more clustered. It also reports
+// whether that time clearly stands out, with a spread under half the mean over the period.
+func clusterTime(ctx context.Context, robots []robot, rs roomSize, period int, coordinate func(shared.Point) int) (int, bool, error) {
+	best, bestSpread, totalSpread := 0, math.MaxInt, 0
+	values := make([]int, len(robots))
+	for t := range period {
+		if err := ctx.Err(); err != nil {
+			return 0, false, err
 		}
 
-		for _, row := range grid.Rows() {
-			consecutiveFilled := 0
-			for _, char := range row {
-				if char == '#' {
-					consecutiveFilled++
-				} else {
-					if consecutiveFilled == 31 {
-						fmt.Println("Part 2:", t)
-						return
-					}
-					consecutiveFilled = 0
-				}
-			}
+		for i, bot := range robots {
+			values[i] = coordinate(bot.positionAtTime(t, rs))
+		}
+		s := spread(values)
+		if s < bestSpread {
+			best, bestSpread = t, s
 		}
-		t++
+		totalSpread += s
 	}
+	return best, 2*bestSpread*period < totalSpread, nil
 }
 
-func Run() {
-	rawInput, err := shared.ReadFileByLine("days/day14/input.txt")
+// treeTime finds when the robots draw the tree. The X and Y coordinates bunch up at times that repeat with
+// the room's width and height, and the Chinese remainder theorem combines the two into the moment both do.
+// It returns errNoTree if the robots are too few or never bunch up in both coordinates.
+func treeTime(ctx context.Context, robots []robot, rs roomSize) (int, error) {
+	tx, xClustered, err := clusterTime(ctx, robots, rs, rs.width, func(p shared.Point) int { return p.X })
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return 0, err
 	}
-	robots, err := parseInput(rawInput)
+	ty, yClustered, err := clusterTime(ctx, robots, rs, rs.height, func(p shared.Point) int { return p.Y })
 	if err != nil {
-		log.Fatalf("Error: %v", err)
+		return 0, err
+	}
+
+	if len(robots) < minTreeRobots || !xClustered || !yClustered {
+		return 0, errNoTree
+	}
+
+	t, _, err := mathx.CRT([]int{tx, ty}, []int{rs.width, rs.height})
+	return t, err
+}
+
+// drawFrame shows where the robots are at time t, such as the moment they draw the tree.
+func drawFrame(ctx context.Context, robots []robot, rs roomSize, t int) {
+	if !render.Enabled(ctx) {
 		return
 	}
 
+	occupied := shared.NewSet[shared.Point]()
+	for _, bot := range robots {
+		occupied.Add(bot.positionAtTime(t, rs))
+	}
+
+	cells := render.Cells[rune]{
+		Max: shared.NewPoint(rs.width-1, rs.height-1),
+		Get: func(p shared.Point) rune {
+			if occupied.Contains(p) {
+				return '#'
+			}
+			return '.'
+		},
+	}
+	palette := map[rune]color.Color{'#': color.RGBA{G: 0xc0, A: 0xff}, '.': color.Black}
+	render.Report(ctx, "tree", cells, render.Options[rune]{Palette: palette, Scale: 4})
+}
+
+func part2(ctx context.Context, robots []robot) (int, error) {
 	rs := newRoomSize(roomWidth, roomHeight)
+	t, err := treeTime(ctx, robots, rs)
+	if err != nil {
+		return 0, err
+	}
+
+	drawFrame(ctx, robots, rs, t)
+	return t, nil
+}
+
+func parse(r io.Reader) ([]robot, error) {
+	rawInput, err := shared.ReadByLine(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInput(rawInput)
+}
 
-	part1(robots, rs)
-	part2(robots, rs)
+func init() {
+	solver.Register(14, solver.New(parse, part1, part2))
 }
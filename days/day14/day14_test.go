@@ -0,0 +1,136 @@
+package day14
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `p=0,4 v=3,-3
+p=6,3 v=-1,-3
+p=10,3 v=-1,2
+p=2,0 v=2,-1
+p=0,0 v=1,3
+p=3,0 v=-2,-2
+p=7,6 v=-1,-3
+p=3,0 v=-1,-2
+p=9,3 v=2,3
+p=7,3 v=-1,2
+p=2,4 v=2,-3
+p=9,5 v=-3,-3`
+
+var exampleRoom = newRoomSize(11, 7)
+
+func TestSafetyFactor(t *testing.T) {
+	robots := solvertest.Parse(t, parse, example)
+
+	if got := safetyFactor(robots, exampleRoom, 100); got != 12 {
+		t.Errorf("got %d, want 12", got)
+	}
+}
+
+func TestPositionAtTime(t *testing.T) {
+	bot, err := parseRobot("p=2,4 v=2,-3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		t    int
+		want shared.Point
+	}{
+		{t: 0, want: shared.NewPoint(2, 4)},
+		{t: 1, want: shared.NewPoint(4, 1)},
+		{t: 2, want: shared.NewPoint(6, 5)},
+		{t: 3, want: shared.NewPoint(8, 2)},
+		{t: 4, want: shared.NewPoint(10, 6)},
+		{t: 5, want: shared.NewPoint(1, 3)},
+	}
+
+	for _, tt := range tests {
+		if got := bot.positionAtTime(tt.t, exampleRoom); got != tt.want {
+			t.Errorf("position at %d = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestTreeTime(t *testing.T) {
+	// Two thirds of the robots gather in a small square at the chosen time, the rest are scattered.
+	const want = 6789
+	rs := newRoomSize(roomWidth, roomHeight)
+	rng := rand.New(rand.NewSource(14))
+
+	robots := make([]robot, 500)
+	for i := range robots {
+		target := shared.NewPoint(rng.Intn(rs.width), rng.Intn(rs.height))
+		if i%3 != 0 {
+			target = shared.NewPoint(40+rng.Intn(15), 50+rng.Intn(15))
+		}
+		v := newVelocity(rng.Intn(201)-100, rng.Intn(201)-100)
+		start := shared.NewPoint(target.X-v.x*want, target.Y-v.y*want).Wrap(rs.width, rs.height)
+		robots[i] = newRobot(start, v)
+	}
+
+	got, err := treeTime(context.Background(), robots, rs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+
+func TestTreeTimeWithoutTree(t *testing.T) {
+	rs := newRoomSize(roomWidth, roomHeight)
+	rng := rand.New(rand.NewSource(14))
+
+	scattered := make([]robot, 500)
+	for i := range scattered {
+		position := shared.NewPoint(rng.Intn(rs.width), rng.Intn(rs.height))
+		scattered[i] = newRobot(position, newVelocity(rng.Intn(201)-100, rng.Intn(201)-100))
+	}
+
+	tests := []struct {
+		name   string
+		robots []robot
+	}{
+		{name: "example", robots: solvertest.Parse(t, parse, example)},
+		{name: "scattered", robots: scattered},
+		{name: "no robots"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := treeTime(context.Background(), tt.robots, rs); !errors.Is(err, errNoTree) {
+				t.Errorf("got %d, %v, want %v", got, err, errNoTree)
+			}
+		})
+	}
+}
+
+func TestPart2StopsWhenCancelled(t *testing.T) {
+	robots := solvertest.Parse(t, parse, example)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := part2(ctx, robots); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPartInput(b, parse, part2)
+}
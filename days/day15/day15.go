@@ -1,18 +1,20 @@
 package day15
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
-var moveMap = map[rune]func(shared.Point) shared.Point{
-	'>': shared.Point.Right,
-	'<': shared.Point.Left,
-	'^': shared.Point.Up,
-	'v': shared.Point.Down,
+type input struct {
+	grid  shared.Grid[rune]
+	pos   shared.Point
+	moves []shared.Direction
 }
 
 type wideBox struct {
@@ -41,15 +43,15 @@ func parseGrid(rawGrid []string) (shared.Grid[rune], shared.Point) {
 	return shared.NewGrid(grid), startingPoint
 }
 
-func findNewBoxPosition(grid shared.Grid[rune], move func(shared.Point) shared.Point, boxPos shared.Point) shared.Point {
-	newBoxPos := move(boxPos)
+func findNewBoxPosition(grid shared.Grid[rune], dir shared.Direction, boxPos shared.Point) shared.Point {
+	newBoxPos := boxPos.Move(dir, 1)
 
 	for {
 		if grid.Get(newBoxPos) == '#' {
 			return boxPos
 		}
 		if grid.Get(newBoxPos) == 'O' {
-			newBoxPos = move(newBoxPos)
+			newBoxPos = newBoxPos.Move(dir, 1)
 			continue
 		}
 		break
@@ -58,17 +60,17 @@ func findNewBoxPosition(grid shared.Grid[rune], move func(shared.Point) shared.P
 	return newBoxPos
 }
 
-func findConnectedWideBoxes(grid shared.Grid[rune], move func(shared.Point) shared.Point, box wideBox) []wideBox {
+func findConnectedWideBoxes(grid shared.Grid[rune], dir shared.Direction, box wideBox) []wideBox {
 	var connectedBoxes []wideBox
 
-	nextLeft, nextRight := move(box.left), move(box.right)
+	nextLeft, nextRight := box.left.Move(dir, 1), box.right.Move(dir, 1)
 	nextLeftChar, nextRightChar := grid.Get(nextLeft), grid.Get(nextRight)
 
 	addConnectedBox := func(left, right shared.Point) {
 		connectedBox := newWideBox(left, right)
 		if connectedBox != box {
 			connectedBoxes = append(connectedBoxes, connectedBox)
-			connectedBoxes = append(connectedBoxes, findConnectedWideBoxes(grid, move, connectedBox)...)
+			connectedBoxes = append(connectedBoxes, findConnectedWideBoxes(grid, dir, connectedBox)...)
 		}
 	}
 
@@ -87,8 +89,8 @@ func findConnectedWideBoxes(grid shared.Grid[rune], move func(shared.Point) shar
 	return connectedBoxes
 }
 
-func checkWideBoxCanMove(grid shared.Grid[rune], move func(shared.Point) shared.Point, box wideBox) bool {
-	nextLeft, nextRight := move(box.left), move(box.right)
+func checkWideBoxCanMove(grid shared.Grid[rune], dir shared.Direction, box wideBox) bool {
+	nextLeft, nextRight := box.left.Move(dir, 1), box.right.Move(dir, 1)
 	nextLeftChar, nextRightChar := grid.Get(nextLeft), grid.Get(nextRight)
 
 	if nextLeftChar == '#' || nextRightChar == '#' {
@@ -98,8 +100,8 @@ func checkWideBoxCanMove(grid shared.Grid[rune], move func(shared.Point) shared.
 	return true
 }
 
-func moveWideBox(grid *shared.Grid[rune], move func(shared.Point) shared.Point, box wideBox) {
-	nextLeft, nextRight := move(box.left), move(box.right)
+func moveWideBox(grid *shared.Grid[rune], dir shared.Direction, box wideBox) {
+	nextLeft, nextRight := box.left.Move(dir, 1), box.right.Move(dir, 1)
 
 	grid.Set(nextLeft, '[')
 	grid.Set(nextRight, ']')
@@ -117,11 +119,29 @@ func calculateScore(grid shared.Grid[rune], char rune) int {
 	return tot
 }
 
-func parseInput(rawInput [][]string) (shared.Grid[rune], shared.Point, string) {
-	rawGrid := rawInput[0]
-	grid, pos := parseGrid(rawGrid)
-	moves := strings.Join(rawInput[1], "")
-	return grid, pos, moves
+func parseMoves(rawMoves []string) ([]shared.Direction, error) {
+	var moves []shared.Direction
+	for _, char := range strings.Join(rawMoves, "") {
+		dir, err := shared.ParseDirection(char)
+		if err != nil {
+			return nil, fmt.Errorf("parsing moves: %w", err)
+		}
+		moves = append(moves, dir)
+	}
+	return moves, nil
+}
+
+func parseInput(rawInput [][]string) (input, error) {
+	if len(rawInput) < 2 {
+		return input{}, errors.New("expected a grid and moves")
+	}
+
+	grid, pos := parseGrid(rawInput[0])
+	moves, err := parseMoves(rawInput[1])
+	if err != nil {
+		return input{}, err
+	}
+	return input{grid: grid, pos: pos, moves: moves}, nil
 }
 
 func widenGrid(grid shared.Grid[rune]) shared.Grid[rune] {
@@ -159,9 +179,9 @@ func clearCurrentPositions(grid *shared.Grid[rune], boxes []wideBox) {
 	}
 }
 
-func moveWideBoxes(grid *shared.Grid[rune], move func(shared.Point) shared.Point, boxes []wideBox) {
+func moveWideBoxes(grid *shared.Grid[rune], dir shared.Direction, boxes []wideBox) {
 	for _, box := range boxes {
-		moveWideBox(grid, move, box)
+		moveWideBox(grid, dir, box)
 	}
 }
 
@@ -172,22 +192,24 @@ func createNextWideBox(next shared.Point, char rune) wideBox {
 	return newWideBox(shared.NewPoint(next.X-1, next.Y), next)
 }
 
-func allBoxesCanMove(grid shared.Grid[rune], move func(shared.Point) shared.Point, boxes []wideBox) bool {
+func allBoxesCanMove(grid shared.Grid[rune], dir shared.Direction, boxes []wideBox) bool {
 	for _, box := range boxes {
-		if !checkWideBoxCanMove(grid, move, box) {
+		if !checkWideBoxCanMove(grid, dir, box) {
 			return false
 		}
 	}
 	return true
 }
 
-func part1(grid shared.Grid[rune], pos shared.Point, moves string) {
+func part1(_ context.Context, in input) (int, error) {
+	grid, pos := in.grid.Clone(), in.pos
+
 	var next shared.Point
 	var newBoxPos shared.Point
 	var nextChar rune
 
-	for _, move := range moves {
-		next = moveMap[move](pos)
+	for _, dir := range in.moves {
+		next = pos.Move(dir, 1)
 		nextChar = grid.Get(next)
 
 		if nextChar == '#' {
@@ -195,7 +217,7 @@ func part1(grid shared.Grid[rune], pos shared.Point, moves string) {
 		}
 
 		if nextChar == 'O' {
-			newBoxPos = findNewBoxPosition(grid, moveMap[move], next)
+			newBoxPos = findNewBoxPosition(grid, dir, next)
 			if newBoxPos == next {
 				continue
 			}
@@ -207,13 +229,15 @@ func part1(grid shared.Grid[rune], pos shared.Point, moves string) {
 		pos = next
 	}
 
-	score := calculateScore(grid, 'O')
-	fmt.Println("Part 1:", score)
+	return calculateScore(grid, 'O'), nil
 }
 
-func part2(grid shared.Grid[rune], pos shared.Point, moves string) {
-	for _, move := range moves {
-		next := moveMap[move](pos)
+func part2(_ context.Context, in input) (int, error) {
+	grid := widenGrid(in.grid)
+	pos := shared.NewPoint(2*in.pos.X, in.pos.Y)
+
+	for _, dir := range in.moves {
+		next := pos.Move(dir, 1)
 		nextChar := grid.Get(next)
 
 		if nextChar == '#' {
@@ -223,12 +247,12 @@ func part2(grid shared.Grid[rune], pos shared.Point, moves string) {
 		if nextChar == '[' || nextChar == ']' {
 			box := createNextWideBox(next, nextChar)
 
-			connectedBoxes := findConnectedWideBoxes(grid, moveMap[move], box)
+			connectedBoxes := findConnectedWideBoxes(grid, dir, box)
 			connectedBoxes = append(connectedBoxes, box)
 
-			if allBoxesCanMove(grid, moveMap[move], connectedBoxes) {
+			if allBoxesCanMove(grid, dir, connectedBoxes) {
 				clearCurrentPositions(&grid, connectedBoxes)
-				moveWideBoxes(&grid, moveMap[move], connectedBoxes)
+				moveWideBoxes(&grid, dir, connectedBoxes)
 			} else {
 				continue
 			}
@@ -237,20 +261,18 @@ func part2(grid shared.Grid[rune], pos shared.Point, moves string) {
 		pos = next
 	}
 
-	fmt.Println("Part 2:", calculateScore(grid, '['))
+	return calculateScore(grid, '['), nil
 }
 
-func Run() {
-	rawInput, err := shared.ReadFileByBlankLine("days/day15/input.txt")
+func parse(r io.Reader) (input, error) {
+	rawInput, err := shared.ReadByBlankLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
-	grid, pos, moves := parseInput(rawInput)
-	wideGrid := widenGrid(grid)
-	wideStartingPos := shared.NewPoint(2*pos.X, pos.Y)
+	return parseInput(rawInput)
+}
 
-	part1(grid.Clone(), pos, moves)
-	part2(wideGrid, wideStartingPos, moves)
+func init() {
+	solver.Register(15, solver.New(parse, part1, part2))
 }
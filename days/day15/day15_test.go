@@ -0,0 +1,99 @@
+package day15
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const smallExample = `########
+#..O.O.#
+##@.O..#
+#...O..#
+#.#.O..#
+#...O..#
+#......#
+########
+
+<^^>>>vv<v>>v<<`
+
+const largeExample = `##########
+#..O..O.O#
+#......O.#
+#.OO..O.O#
+#..O@..O.#
+#O#..O...#
+#O..O..O.#
+#.OO.O.OO#
+#....O...#
+##########
+
+<vv>^<v^>v>^vv^v>v<>v^v<v<^vv<<<^><<><>>v<vvv<>^v^>^<<<><<v<<<v^vv^v>^
+vvv<<^>^v^^><<>>><>^<<><^vv^^<>vvv<>><^^v>^>vv<>v<<<<v<^v>^<^^>>>^<v<v
+><>vv>v^v^<>><>>>><^^>vv>v<^^^>>v^v^<^^>v^^>v^<^v>v<>>v^v^<v>v^^<^^vv<
+<<v<^>>^^^^>>>v^<>vvv^><v<<<>^^^vv^<vvv>^>v<^^^^v<>^>vvvv><>>v^<<^^^^^
+^><^><>>><>^^<<^^v>>><^<v>^<vv>>v>>>^v><>^v><<<<v>>v<v<v>vvv>^<><<>^><
+^>><>^v<><^vvv<^^<><v<<<<<><^v<<<><<<^^<v<^^^><^>>^<v^><<<^>>^v<v^v<v^
+>^>>^v>vv>^<<^v<>><<><<v<<v><>v<^vv<<<>^^v^>^^>>><<^v>>v^v><^^>>^<>vv^
+<><^^>^^^<><vvvvv^v<v<<>^v<v>v<<^><<><<><<<^^<<<^<<>><<><^^^>^^<>^>v<>
+^^>vv<^v^v<vv>^<><v<^v>^^^>>>^^vvv^>vvv<>>>^<^>>>>>^<<^v>^vvv<>^<><<v>
+v^^>>><<^^<>>^v^<v^vv<>v^<<>^<^v^v><^<<<><<^<v><v<>vv>>v><v^<vv<>v^<<^`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, input) (int, error)
+		want  int
+	}{
+		{name: "part 1 small", input: smallExample, part: part1, want: 2028},
+		{name: "part 1 large", input: largeExample, part: part1, want: 10092},
+		{name: "part 2 large", input: largeExample, part: part2, want: 9021},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func TestPartsDoNotModifyInput(t *testing.T) {
+	in := solvertest.Parse(t, parse, largeExample)
+	before := calculateScore(in.grid, 'O')
+
+	for _, part := range []func(context.Context, input) (int, error){part1, part2} {
+		if _, err := part(context.Background(), in); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if after := calculateScore(in.grid, 'O'); after != before {
+		t.Errorf("parsed grid changed: score %d became %d", before, after)
+	}
+}
+
+func TestWidenGrid(t *testing.T) {
+	grid := solvertest.Parse(t, parse, "#####\n#.O@#\n#####\n\n<").grid
+
+	wide := widenGrid(grid)
+	want := []string{"##########", "##..[]@.##", "##########"}
+	for y, row := range wide.Rows() {
+		if string(row) != want[y] {
+			t.Errorf("row %d = %q, want %q", y, string(row), want[y])
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, largeExample)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, largeExample)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, largeExample)
+}
@@ -1,86 +1,66 @@
 package day16
 
 import (
-	"container/heap"
-	"fmt"
-	"log"
+	"context"
+	"errors"
+	"image/color"
+	"io"
 	"math"
 
 	"aoc2024/shared"
+	"aoc2024/shared/graph"
+	"aoc2024/shared/render"
+	"aoc2024/shared/solver"
 )
 
-var directionMap = map[direction]int{
-	{0, 1}:  0,
-	{1, 0}:  1,
-	{0, -1}: 2,
-	{-1, 0}: 3,
-}
-
-type direction struct {
-	x int
-	y int
-}
-
-func newDirection(x, y int) direction {
-	return direction{x: x, y: y}
-}
-
 type node struct {
 	point     shared.Point
-	direction direction
+	direction shared.Direction
 }
 
-func newNode(point shared.Point, direction direction) node {
+func newNode(point shared.Point, direction shared.Direction) node {
 	return node{point: point, direction: direction}
 }
 
-type edge struct {
-	destination node
-	cost        int
-}
-
-func newEdge(destination node, cost int) edge {
-	return edge{destination: destination, cost: cost}
+type input struct {
+	grid      shared.Grid[rune]
+	graph     graph.Map[node]
+	startNode node
+	goal      shared.Point
 }
 
-func turnCost(from, to direction) int {
-	turnSteps := (directionMap[to] - directionMap[from]) % 4
-	if turnSteps < 0 {
-		turnSteps += 4
-	}
-
-	if turnSteps == 0 {
+func turnCost(from, to shared.Direction) int {
+	switch to {
+	case from:
 		return 1
-	}
-
-	if turnSteps == 2 {
+	case from.Reverse():
 		return 2001
+	default:
+		return 1001
 	}
-
-	return 1001
 }
 
-func graphFromGrid(grid shared.Grid[rune]) map[node][]edge {
-	graph := make(map[node][]edge)
+func graphFromGrid(grid shared.Grid[rune]) graph.Map[node] {
+	g := make(graph.Map[node])
 	for y, row := range grid.Rows() {
 		for x, char := range row {
 			if char == '#' {
 				continue
 			}
 			current := shared.NewPoint(x, y)
-			updateGraphWithPos(grid, current, graph)
+			updateGraphWithPos(grid, current, g)
 		}
 	}
 
-	return graph
+	return g
 }
 
-func updateGraphWithPos(grid shared.Grid[rune], current shared.Point, graph map[node][]edge) {
-	for currDir := range directionMap {
+func updateGraphWithPos(grid shared.Grid[rune], current shared.Point, g graph.Map[node]) {
+	for _, currDir := range shared.CardinalDirections {
 		currNode := newNode(current, currDir)
 
-		for nextDir := range directionMap {
-			neighbor := shared.NewPoint(current.X+nextDir.x, current.Y+nextDir.y)
+		for _, nextDir := range shared.CardinalDirections {
+			neighbor := current.Move(nextDir, 1)
 
 			if !grid.Contains(neighbor) || grid.Get(neighbor) == '#' {
 				continue
@@ -88,99 +68,23 @@ func updateGraphWithPos(grid shared.Grid[rune], current shared.Point, graph map[
 
 			cost := turnCost(currDir, nextDir)
 			neighborNode := newNode(neighbor, nextDir)
-			currEdge := newEdge(neighborNode, cost)
-			graph[currNode] = append(graph[currNode], currEdge)
+			g[currNode] = append(g[currNode], graph.NewEdge(neighborNode, cost))
 		}
 	}
 }
 
-func findGoal(grid shared.Grid[rune]) shared.Point {
-	for y, row := range grid.Rows() {
-		for x, char := range row {
-			if char == 'E' {
-				return shared.NewPoint(x, y)
-			}
-		}
-	}
-	return shared.Point{}
-}
-
-func dijkstra(graph map[node][]edge, start node, goal shared.Point) (int, map[node][]node, map[node]int) {
-	distances := make(map[node]int)
-	predecessors := make(map[node][]node)
-
-	pq := shared.NewPriorityQueue[node]()
-	heap.Init(&pq)
-
-	for nd := range graph {
-		distances[nd] = math.MaxInt
-		predecessors[nd] = nil
-	}
-
-	distances[start] = 0
-	startEntry := shared.NewPriorityQueueEntry(start, 0)
-	heap.Push(&pq, &startEntry)
-
+func shortestDistanceTo(distances map[node]int, goal shared.Point) (int, error) {
 	shortestDistance := math.MaxInt
-
-	for pq.Len() > 0 {
-		current := heap.Pop(&pq).(*shared.PriorityQueueEntry[node])
-		if distances[current.Value] < current.Priority {
-			continue
-		}
-
-		if current.Value.point == goal {
-			if distances[current.Value] < shortestDistance {
-				shortestDistance = distances[current.Value]
-			}
-		}
-
-		for _, neighbor := range graph[current.Value] {
-			destination := neighbor.destination
-			alternative := distances[current.Value] + neighbor.cost
-
-			if alternative < distances[destination] {
-				distances[destination] = alternative
-				predecessors[destination] = []node{current.Value}
-				newEntry := shared.NewPriorityQueueEntry(destination, alternative)
-				heap.Push(&pq, &newEntry)
-			} else if alternative == distances[destination] {
-				predecessors[destination] = append(predecessors[destination], current.Value)
-			}
+	for n, d := range distances {
+		if n.point == goal && d < shortestDistance {
+			shortestDistance = d
 		}
 	}
 
-	return shortestDistance, predecessors, distances
-}
-
-func reconstructPaths(current node, currentPath []shared.Point, predecessors map[node][]node, start node, allPaths *[][]shared.Point) {
-	currentPath = append([]shared.Point{current.point}, currentPath...)
-
-	if current == start {
-		*allPaths = append(*allPaths, currentPath)
-		return
-	}
-
-	for _, pred := range predecessors[current] {
-		reconstructPaths(pred, currentPath, predecessors, start, allPaths)
+	if shortestDistance == math.MaxInt {
+		return 0, errors.New("goal is not reachable")
 	}
-}
-
-func GetAllShortestPaths(predecessors map[node][]node, start, goal node) [][]shared.Point {
-	var allPaths [][]shared.Point
-
-	reconstructPaths(goal, nil, predecessors, start, &allPaths)
-	return allPaths
-}
-
-func GetPointsOnPaths(paths [][]shared.Point) shared.Set[shared.Point] {
-	pointsSet := shared.NewSet[shared.Point]()
-	for _, path := range paths {
-		for _, p := range path {
-			pointsSet.Add(p)
-		}
-	}
-	return *pointsSet
+	return shortestDistance, nil
 }
 
 func getGoalNodes(distances map[node]int, goal shared.Point, shortestDistance int) []node {
@@ -193,40 +97,68 @@ func getGoalNodes(distances map[node]int, goal shared.Point, shortestDistance in
 	return goalNodes
 }
 
-func getAllShortestPathsAllGoals(goalNodes []node, predecessors map[node][]node, startNode node) [][]shared.Point {
-	var allPaths [][]shared.Point
-	for _, g := range goalNodes {
-		paths := GetAllShortestPaths(predecessors, startNode, g)
-		allPaths = append(allPaths, paths...)
+func part1(ctx context.Context, in input) (int, error) {
+	isGoal := func(n node) bool { return n.point == in.goal }
+	// Moves add 1 and turns 1000, so the queued scores stay within a small range and fit a bucket queue.
+	result, err := graph.DijkstraQueue(ctx, in.graph, in.startNode, isGoal, shared.NewBucketQueue[node]())
+	if err != nil {
+		return 0, err
 	}
-	return allPaths
+
+	if !result.Found {
+		return 0, errors.New("goal is not reachable")
+	}
+	return result.Dist[result.Goal], nil
 }
 
-func part1(shortestDistance int) {
-	fmt.Println("Part 1:", shortestDistance)
+func part2(ctx context.Context, in input) (int, error) {
+	sp, err := graph.AllShortestPaths(ctx, in.graph, in.startNode)
+	if err != nil {
+		return 0, err
+	}
+
+	shortestDistance, err := shortestDistanceTo(sp.Dist, in.goal)
+	if err != nil {
+		return 0, err
+	}
+
+	goalNodes := getGoalNodes(sp.Dist, in.goal, shortestDistance)
+	points := shared.NewSet[shared.Point]()
+	for _, n := range sp.NodesOnPaths(goalNodes...).Items() {
+		points.Add(n.point)
+	}
+
+	drawBestTiles(ctx, in.grid, points)
+	return points.Size(), nil
 }
 
-func part2(shortestDistance int, predecessors map[node][]node, distances map[node]int, goal shared.Point, startNode node) {
-	goalNodes := getGoalNodes(distances, goal, shortestDistance)
-	allPaths := getAllShortestPathsAllGoals(goalNodes, predecessors, startNode)
-	allPoints := GetPointsOnPaths(allPaths)
+// drawBestTiles shows the maze with the tiles on any best path highlighted.
+func drawBestTiles(ctx context.Context, grid shared.Grid[rune], tiles *shared.Set[shared.Point]) {
+	if !render.Enabled(ctx) {
+		return
+	}
 
-	fmt.Println("Part 2:", allPoints.Size())
+	opts := render.Options[rune]{
+		Palette:  map[rune]color.Color{'#': color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff}},
+		Default:  color.White,
+		Overlays: []render.Overlay{{Points: tiles.Items(), Color: color.RGBA{R: 0xe0, G: 0x30, B: 0x30, A: 0xff}}},
+		Scale:    4,
+	}
+	render.Report(ctx, "best-tiles", render.FromGrid(grid), opts)
 }
 
-func Run() {
-	grid, start, err := shared.ReadFileToRuneGridWithStartingPoint("days/day16/input.txt", 'S')
+func parse(r io.Reader) (input, error) {
+	grid, start, goal, err := shared.ReadToRuneGridWithStartingPointAndGoal(r, 'S', 'E')
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
-	goal := findGoal(grid)
-	graph := graphFromGrid(grid)
-	startNode := newNode(start, newDirection(1, 0))
+	g := graphFromGrid(grid)
+	startNode := newNode(start, shared.East)
 
-	shortestDistance, predecessors, distances := dijkstra(graph, startNode, goal)
+	return input{grid: grid, graph: g, startNode: startNode, goal: goal}, nil
+}
 
-	part1(shortestDistance)
-	part2(shortestDistance, predecessors, distances, goal, startNode)
+func init() {
+	solver.Register(16, solver.New(parse, part1, part2))
 }
@@ -0,0 +1,94 @@
+package day16
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const firstExample = `###############
+#.......#....E#
+#.#.###.#.###.#
+#.....#.#...#.#
+#.###.#####.#.#
+#.#.#.......#.#
+#.#.#####.###.#
+#...........#.#
+###.#.#####.#.#
+#...#.....#.#.#
+#.#.#.###.#.#.#
+#.....#...#.#.#
+#.###.#.#.#.#.#
+#S..#.....#...#
+###############`
+
+const secondExample = `#################
+#...#...#...#..E#
+#.#.#.#.#.#.#.#.#
+#.#.#.#...#...#.#
+#.#.#.#.###.#.#.#
+#...#.#.#.....#.#
+#.#.#.#.#.#####.#
+#.#...#.#.#.....#
+#.#.#####.#.###.#
+#.#.#.......#...#
+#.#.###.#####.###
+#.#.#...#.....#.#
+#.#.#.#####.###.#
+#.#.#.........#.#
+#.#.#.#########.#
+#S#.............#
+#################`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, input) (int, error)
+		want  int
+	}{
+		{name: "part 1 first example", input: firstExample, part: part1, want: 7036},
+		{name: "part 1 second example", input: secondExample, part: part1, want: 11048},
+		{name: "part 2 first example", input: firstExample, part: part2, want: 45},
+		{name: "part 2 second example", input: secondExample, part: part2, want: 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func TestTurnCost(t *testing.T) {
+	tests := []struct {
+		from shared.Direction
+		to   shared.Direction
+		want int
+	}{
+		{from: shared.East, to: shared.East, want: 1},
+		{from: shared.East, to: shared.North, want: 1001},
+		{from: shared.North, to: shared.East, want: 1001},
+		{from: shared.East, to: shared.West, want: 2001},
+	}
+
+	for _, tt := range tests {
+		if got := turnCost(tt.from, tt.to); got != tt.want {
+			t.Errorf("turnCost(%v, %v) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, firstExample)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, firstExample)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, firstExample)
+}
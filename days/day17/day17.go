@@ -1,14 +1,23 @@
 package day17
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"strconv"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver"
 )
 
+type input struct {
+	cpu     *computer
+	program []int
+}
+
 type computer struct {
 	registerA int
 	registerB int
@@ -20,7 +29,9 @@ func newComputer(a int, b int, c int) *computer {
 	return &computer{registerA: a, registerB: b, registerC: c, pointer: 0}
 }
 
-func (cpu *computer) run(program []int) ([]int, error) {
+// run executes the program until the pointer leaves it. Jumps are the only way a program can loop, so that is
+// where it checks whether ctx is done.
+func (cpu *computer) run(ctx context.Context, program []int) ([]int, error) {
 	var (
 		err         error
 		instruction int
@@ -42,6 +53,7 @@ func (cpu *computer) run(program []int) ([]int, error) {
 			err = cpu.bst(operand)
 		case 3:
 			cpu.jnz(operand)
+			err = ctx.Err()
 		case 4:
 			cpu.bxc()
 		case 5:
@@ -87,7 +99,10 @@ func (cpu *computer) adv(operand int) error {
 		return fmt.Errorf("error running adv: %w", err)
 	}
 
-	cpu.registerA = cpu.registerA / twoToThePowerOf(exp)
+	cpu.registerA, err = divideByPowerOfTwo(cpu.registerA, exp)
+	if err != nil {
+		return fmt.Errorf("error running adv: %w", err)
+	}
 	cpu.pointer += 2
 	return nil
 }
@@ -136,7 +151,10 @@ func (cpu *computer) bdv(operand int) error {
 		return fmt.Errorf("error running bdv: %w", err)
 	}
 
-	cpu.registerB = cpu.registerA / twoToThePowerOf(exp)
+	cpu.registerB, err = divideByPowerOfTwo(cpu.registerA, exp)
+	if err != nil {
+		return fmt.Errorf("error running bdv: %w", err)
+	}
 	cpu.pointer += 2
 	return nil
 }
@@ -144,45 +162,63 @@ func (cpu *computer) bdv(operand int) error {
 func (cpu *computer) cdv(operand int) error {
 	exp, err := cpu.comboOperand(operand)
 	if err != nil {
-		return fmt.Errorf("error running bdv: %w", err)
+		return fmt.Errorf("error running cdv: %w", err)
 	}
 
-	cpu.registerC = cpu.registerA / twoToThePowerOf(exp)
+	cpu.registerC, err = divideByPowerOfTwo(cpu.registerA, exp)
+	if err != nil {
+		return fmt.Errorf("error running cdv: %w", err)
+	}
 	cpu.pointer += 2
 	return nil
 }
 
-func (cpu *computer) runDisassembledProgram() int {
-	var err error
-	var output int
-
-	err = cpu.bst(4)
+func (cpu *computer) runDisassembledProgram() (int, error) {
+	err := cpu.bst(4)
 	if err != nil {
-		panic("disassembled program should not error")
+		return 0, fmt.Errorf("error running disassembled program: %w", err)
 	}
 	cpu.bxl(7)
 	err = cpu.cdv(5)
 	if err != nil {
-		panic("disassembled program should not error")
+		return 0, fmt.Errorf("error running disassembled program: %w", err)
 	}
 	cpu.bxc()
 	cpu.bxl(4)
-	output, err = cpu.out(5)
+	output, err := cpu.out(5)
 	if err != nil {
-		panic("disassembled program should not error")
+		return 0, fmt.Errorf("error running disassembled program: %w", err)
 	}
-	return output
+	return output, nil
 }
 
-func twoToThePowerOf(exp int) int {
-	return 1 << exp
+// divideByPowerOfTwo returns n / 2^exp, which is 0 once 2^exp no longer fits in an int. A negative exponent,
+// which a negative register can give as the combo operand, is an error.
+func divideByPowerOfTwo(n int, exp int) (int, error) {
+	if exp < 0 {
+		return 0, fmt.Errorf("negative exponent: %d", exp)
+	}
+
+	divisor, ok := mathx.Pow(2, exp)
+	if !ok {
+		return 0, nil
+	}
+	return n / divisor, nil
 }
 
 func parseComputerRegisters(registersRaw []string) (*computer, error) {
+	if len(registersRaw) != 3 {
+		return nil, fmt.Errorf("error parsing computer: expected 3 registers, got %d", len(registersRaw))
+	}
+
 	var registers [3]int
 	for i, registerRaw := range registersRaw {
-		registerStr := strings.Split(registerRaw, " ")[2]
-		register, err := strconv.Atoi(registerStr)
+		fields := strings.Fields(registerRaw)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("error parsing computer: invalid register %q", registerRaw)
+		}
+
+		register, err := strconv.Atoi(fields[2])
 		if err != nil {
 			return nil, fmt.Errorf("error parsing computer: %w", err)
 		}
@@ -192,7 +228,10 @@ func parseComputerRegisters(registersRaw []string) (*computer, error) {
 }
 
 func parseProgram(programRaw string) ([]int, error) {
-	instructionsRaw := strings.Split(programRaw, " ")[1]
+	_, instructionsRaw, found := strings.Cut(programRaw, " ")
+	if !found {
+		return nil, fmt.Errorf("error parsing program: invalid program %q", programRaw)
+	}
 	instructionsSplit := strings.Split(instructionsRaw, ",")
 
 	program := make([]int, len(instructionsSplit))
@@ -206,48 +245,63 @@ func parseProgram(programRaw string) ([]int, error) {
 	return program, nil
 }
 
-func parseInput(rawInput [][]string) (*computer, []int, error) {
+func parseInput(rawInput [][]string) (input, error) {
+	if len(rawInput) < 2 || len(rawInput[1]) == 0 {
+		return input{}, errors.New("error parsing input: expected registers and a program")
+	}
+
 	cpu, err := parseComputerRegisters(rawInput[0])
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing input: %w", err)
+		return input{}, fmt.Errorf("error parsing input: %w", err)
 	}
 
 	program, err := parseProgram(rawInput[1][0])
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing input: %w", err)
+		return input{}, fmt.Errorf("error parsing input: %w", err)
 	}
 
-	return cpu, program, nil
+	return input{cpu: cpu, program: program}, nil
 }
 
-func reverseEngineer(program []int, target int) int {
+func reverseEngineer(ctx context.Context, program []int, target int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
 	programLen := len(program)
 	lastIndex := programLen - 1
 
 	if programLen == 0 {
-		return target
+		return target, nil
 	}
 
 	for x := 0; x <= 7; x++ {
 		cpu := newComputer(target*8+x, 0, 0)
-		output := cpu.runDisassembledProgram()
+		output, err := cpu.runDisassembledProgram()
+		if err != nil {
+			return 0, err
+		}
 
 		if output == program[lastIndex] {
-			previous := reverseEngineer(program[:lastIndex], cpu.registerA)
+			previous, err := reverseEngineer(ctx, program[:lastIndex], cpu.registerA)
+			if err != nil {
+				return 0, err
+			}
 
 			if previous == -1 {
 				continue
 			}
-			return previous
+			return previous, nil
 		}
 	}
-	return -1
+	return -1, nil
 }
 
-func part1(cpu *computer, program []int) error {
-	output, err := cpu.run(program)
+func part1(ctx context.Context, in input) (string, error) {
+	cpu := *in.cpu
+	output, err := cpu.run(ctx, in.program)
 	if err != nil {
-		return fmt.Errorf("error running part1: %w", err)
+		return "", fmt.Errorf("error running part1: %w", err)
 	}
 
 	strSlice := make([]string, len(output))
@@ -255,33 +309,30 @@ func part1(cpu *computer, program []int) error {
 		strSlice[i] = strconv.Itoa(value)
 	}
 
-	fmt.Println("Part 1:", strings.Join(strSlice, ","))
-	return nil
-}
-
-func part2(program []int) {
-	a := reverseEngineer(program, 0)
-	fmt.Println("Part 2:", a)
+	return strings.Join(strSlice, ","), nil
 }
 
-func Run() {
-	rawInput, err := shared.ReadFileByBlankLine("days/day17/input.txt")
+func part2(ctx context.Context, in input) (int, error) {
+	registerA, err := reverseEngineer(ctx, in.program, 0)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return 0, fmt.Errorf("error running part2: %w", err)
 	}
 
-	cpu, program, err := parseInput(rawInput)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+	if registerA == -1 {
+		return 0, errors.New("error running part2: no register A value outputs the program")
 	}
+	return registerA, nil
+}
 
-	err = part1(cpu, program)
+func parse(r io.Reader) (input, error) {
+	rawInput, err := shared.ReadByBlankLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
-	part2(program)
+	return parseInput(rawInput)
+}
+
+func init() {
+	solver.Register(17, solver.New(parse, part1, part2))
 }
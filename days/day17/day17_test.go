@@ -0,0 +1,110 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `Register A: 729
+Register B: 0
+Register C: 0
+
+Program: 0,1,5,4,3,0`
+
+func TestPart1(t *testing.T) {
+	solvertest.Part(t, parse, part1, example, "4,6,3,5,6,3,5,2,1,0")
+}
+
+func TestPart1DoesNotModifyInput(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	if _, err := part1(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in.cpu.registerA != 729 || in.cpu.pointer != 0 {
+		t.Errorf("part1 modified the parsed computer: %+v", *in.cpu)
+	}
+}
+
+func TestComputerRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		cpu        *computer
+		program    []int
+		wantOutput []int
+		wantA      int
+		wantB      int
+	}{
+		{name: "bst", cpu: newComputer(0, 0, 9), program: []int{2, 6}, wantB: 1},
+		{name: "out", cpu: newComputer(10, 0, 0), program: []int{5, 0, 5, 1, 5, 4}, wantOutput: []int{0, 1, 2}, wantA: 10},
+		{
+			name:       "adv loop",
+			cpu:        newComputer(2024, 0, 0),
+			program:    []int{0, 1, 5, 4, 3, 0},
+			wantOutput: []int{4, 2, 5, 6, 7, 7, 7, 7, 3, 1, 0},
+			wantA:      0,
+		},
+		{name: "bxl", cpu: newComputer(0, 29, 0), program: []int{1, 7}, wantB: 26},
+		{name: "bxc", cpu: newComputer(0, 2024, 43690), program: []int{4, 0}, wantB: 44354},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := tt.cpu.run(context.Background(), tt.program)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !shared.SlicesEqual(output, tt.wantOutput) {
+				t.Errorf("output = %v, want %v", output, tt.wantOutput)
+			}
+			if tt.cpu.registerA != tt.wantA {
+				t.Errorf("register A = %d, want %d", tt.cpu.registerA, tt.wantA)
+			}
+			if tt.cpu.registerB != tt.wantB {
+				t.Errorf("register B = %d, want %d", tt.cpu.registerB, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestComputerRunInvalidOperand(t *testing.T) {
+	if _, err := newComputer(0, 0, 0).run(context.Background(), []int{5, 7}); err == nil {
+		t.Errorf("expected an error for combo operand 7")
+	}
+}
+
+func TestComputerRunNegativeExponent(t *testing.T) {
+	for _, instruction := range []int{0, 6, 7} {
+		_, err := newComputer(8, -1, 0).run(context.Background(), []int{instruction, 5})
+		if err == nil {
+			t.Errorf("expected an error for instruction %d with a negative combo operand", instruction)
+		}
+	}
+}
+
+func TestComputerRunStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// jnz 0 never leaves the program while register A is not zero.
+	_, err := newComputer(1, 0, 0).run(ctx, []int{3, 0})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPartInput(b, parse, part2)
+}
@@ -1,73 +1,48 @@
 package day18
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 
 	"aoc2024/shared"
+	"aoc2024/shared/graph"
+	"aoc2024/shared/solver"
 )
 
-func findShortestPath(graph map[shared.Point][]shared.Point, start shared.Point, goal shared.Point) (shared.Set[shared.Point], error) {
-	var current shared.Point
+var errNoPath = errors.New("no path found")
 
-	distances := make(map[shared.Point]int)
-	parent := make(map[shared.Point]shared.Point)
-	distances[start] = 0
-	queue := []shared.Point{start}
+func findShortestPath(ctx context.Context, grid shared.Grid[rune], start shared.Point, goal shared.Point) (*shared.IndexSet[shared.Point], error) {
+	isGoal := func(p shared.Point) bool { return p == goal }
+	heuristic := func(p shared.Point) int { return shared.ManhattanDistance(p, goal) }
 
-	for len(queue) > 0 {
-		current, queue = queue[0], queue[1:]
-
-		for _, neighbor := range graph[current] {
-			if _, ok := distances[neighbor]; ok {
-				continue
-			}
-
-			distances[neighbor] = distances[current] + 1
-			parent[neighbor] = current
-			queue = append(queue, neighbor)
-
-			if neighbor == goal {
-				return reconstructPath(parent, start, goal), nil
-			}
-		}
+	result, err := graph.AStar(ctx, graph.GridNeighbors(grid, '#'), start, isGoal, heuristic)
+	if err != nil {
+		return nil, err
 	}
 
-	return *shared.NewSet[shared.Point](), fmt.Errorf("no path found")
-}
-
-func reconstructPath(parent map[shared.Point]shared.Point, start, end shared.Point) shared.Set[shared.Point] {
-	path := shared.NewSet(start)
-	for current := end; current != start; current = parent[current] {
-		path.Add(current)
+	if !result.Found {
+		return nil, errNoPath
 	}
-	return *path
-}
-
-func graphFromGrid(grid shared.Grid[rune]) map[shared.Point][]shared.Point {
-	graph := make(map[shared.Point][]shared.Point)
-	for y, row := range grid.Rows() {
-		for x, char := range row {
-			if char == '#' {
-				continue
-			}
-			current := shared.NewPoint(x, y)
-			for _, neighbor := range current.CardinalNeighbors() {
-				if !grid.Contains(neighbor) || grid.Get(neighbor) == '#' {
-					continue
-				}
-				graph[current] = append(graph[current], neighbor)
-			}
-		}
+	path := shared.NewPointSet(grid.MaxX()+1, grid.MaxY()+1)
+	for _, p := range result.Path() {
+		path.Add(p)
 	}
+	return path, nil
+}
 
-	return graph
+type memorySpace struct {
+	size   int
+	fallen int
 }
 
-func createInitialGrid(lines [][]int) shared.Grid[rune] {
-	grid := shared.NewEmptyGrid(71, 71, '.')
+var puzzleMemorySpace = memorySpace{size: 71, fallen: 1024}
+
+func createInitialGrid(lines [][]int, ms memorySpace) shared.Grid[rune] {
+	grid := shared.NewEmptyGrid(ms.size, ms.size, '.')
 	for i, line := range lines {
-		if i == 1024 {
+		if i == ms.fallen {
 			break
 		}
 		pt := shared.NewPoint(line[0], line[1])
@@ -76,25 +51,35 @@ func createInitialGrid(lines [][]int) shared.Grid[rune] {
 	return grid
 }
 
-func intialSetup(lines [][]int) (shared.Grid[rune], map[shared.Point][]shared.Point, shared.Point, shared.Point) {
-	grid := createInitialGrid(lines)
-	graph := graphFromGrid(grid)
+func intialSetup(lines [][]int, ms memorySpace) (shared.Grid[rune], shared.Point, shared.Point) {
+	grid := createInitialGrid(lines, ms)
 	start := shared.NewPoint(0, 0)
-	end := shared.NewPoint(70, 70)
-	return grid, graph, start, end
+	end := shared.NewPoint(ms.size-1, ms.size-1)
+	return grid, start, end
 }
 
-func part1(path shared.Set[shared.Point]) {
-	fmt.Println("Part 1:", path.Size()-1)
+func shortestPathLength(ctx context.Context, lines [][]int, ms memorySpace) (int, error) {
+	grid, start, end := intialSetup(lines, ms)
+	path, err := findShortestPath(ctx, grid, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	return path.Size() - 1, nil
 }
 
-func part2(
-	lines [][]int,
-	grid shared.Grid[rune],
-	start shared.Point, end shared.Point,
-	path shared.Set[shared.Point],
-) {
-	for i := 1024; i < len(lines); i++ {
+func firstBlockingByte(ctx context.Context, lines [][]int, ms memorySpace) (string, error) {
+	grid, start, end := intialSetup(lines, ms)
+	path, err := findShortestPath(ctx, grid, start, end)
+	if err != nil {
+		return "", err
+	}
+
+	for i := ms.fallen; i < len(lines); i++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
 		x, y := lines[i][0], lines[i][1]
 		pt := shared.NewPoint(x, y)
 		grid.Set(pt, '#')
@@ -102,31 +87,30 @@ func part2(
 			continue
 		}
 
-		graph := graphFromGrid(grid)
-
-		var err error
-		path, err = findShortestPath(graph, start, end)
+		path, err = findShortestPath(ctx, grid, start, end)
+		if errors.Is(err, errNoPath) {
+			return fmt.Sprintf("%v,%v", x, y), nil
+		}
 		if err != nil {
-			fmt.Printf("Part 2: %v,%v\n", x, y)
-			return
+			return "", err
 		}
 	}
+
+	return "", errors.New("path is never blocked")
 }
 
-func Run() {
-	lines, err := shared.ReadFileByLineToSplitInts("days/day18/input.txt", ",")
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+func part1(ctx context.Context, lines [][]int) (int, error) {
+	return shortestPathLength(ctx, lines, puzzleMemorySpace)
+}
 
-	grid, graph, start, end := intialSetup(lines)
-	path, err := findShortestPath(graph, start, end)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+func part2(ctx context.Context, lines [][]int) (string, error) {
+	return firstBlockingByte(ctx, lines, puzzleMemorySpace)
+}
+
+func parse(r io.Reader) ([][]int, error) {
+	return shared.ReadByLineToSplitInts(r, ",")
+}
 
-	part1(path)
-	part2(lines, grid, start, end, path)
+func init() {
+	solver.Register(18, solver.New(parse, part1, part2))
 }
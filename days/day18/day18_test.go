@@ -0,0 +1,80 @@
+package day18
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `5,4
+4,2
+4,5
+3,0
+2,1
+6,3
+2,4
+1,5
+0,6
+3,3
+2,6
+5,1
+1,2
+5,5
+2,5
+6,5
+1,4
+0,4
+6,4
+1,1
+6,1
+1,0
+0,5
+1,6
+2,0`
+
+var exampleMemorySpace = memorySpace{size: 7, fallen: 12}
+
+func TestShortestPathLength(t *testing.T) {
+	lines := solvertest.Parse(t, parse, example)
+
+	got, err := shortestPathLength(context.Background(), lines, exampleMemorySpace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 22 {
+		t.Errorf("got %d, want 22", got)
+	}
+}
+
+func TestFirstBlockingByte(t *testing.T) {
+	lines := solvertest.Parse(t, parse, example)
+
+	got, err := firstBlockingByte(context.Background(), lines, exampleMemorySpace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "6,1" {
+		t.Errorf("got %s, want 6,1", got)
+	}
+}
+
+func TestFirstBlockingByteNeverBlocked(t *testing.T) {
+	lines := solvertest.Parse(t, parse, example)
+
+	if _, err := firstBlockingByte(context.Background(), lines[:15], exampleMemorySpace); err == nil {
+		t.Errorf("expected an error when no byte blocks the path")
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPartInput(b, parse, part2)
+}
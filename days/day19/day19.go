@@ -1,13 +1,21 @@
 package day19
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
+	"math/big"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver"
 )
 
+type input struct {
+	towelMap map[rune][]string
+	designs  []string
+}
+
 func parseTowelPatterns(towelPatterns []string) map[rune][]string {
 	towelMap := make(map[rune][]string)
 
@@ -18,96 +26,87 @@ func parseTowelPatterns(towelPatterns []string) map[rune][]string {
 	return towelMap
 }
 
-func parseInput(inputRaw [][]string) (map[rune][]string, []string) {
+func parseInput(inputRaw [][]string) input {
 	towelMap := parseTowelPatterns(strings.Split(inputRaw[0][0], ", "))
-	return towelMap, inputRaw[1]
+	return input{towelMap: towelMap, designs: inputRaw[1]}
 }
 
-func checkDesign(design string, towelMap map[rune][]string, cache map[string]bool) bool {
-	if val, ok := cache[design]; ok {
-		return val
-	}
-
-	firstLetter := rune(design[0])
-	if _, ok := towelMap[firstLetter]; !ok {
-		cache[design] = false
-		return false
-	}
-
-	for _, pattern := range towelMap[firstLetter] {
-		if strings.HasPrefix(design, pattern) {
-			if len(design) == len(pattern) {
-				cache[design] = true
-				return true
+func newDesignCheck(towelMap map[rune][]string) *shared.Memo[string, bool] {
+	return shared.NewMemo(func(design string, checkDesign func(string) bool) bool {
+		for _, pattern := range towelMap[rune(design[0])] {
+			if !strings.HasPrefix(design, pattern) {
+				continue
 			}
-			if checkDesign(design[len(pattern):], towelMap, cache) {
-				cache[design] = true
+			if len(design) == len(pattern) || checkDesign(design[len(pattern):]) {
 				return true
 			}
 		}
-	}
-	cache[design] = false
-	return false
+		return false
+	})
 }
 
-func countValidCombinations(design string, towelMap map[rune][]string, cache map[string]int) int {
-	if val, ok := cache[design]; ok {
-		return val
-	}
-
-	if len(design) == 0 {
-		return 0
-	}
-
-	firstLetter := rune(design[0])
-	if _, ok := towelMap[firstLetter]; !ok {
-		cache[design] = 0
-		return 0
-	}
+// newCombinationCount counts the ways to build each design. The counts multiply with the design length,
+// so they are Numbers that cannot overflow.
+func newCombinationCount(towelMap map[rune][]string) *shared.Memo[string, mathx.Number] {
+	return shared.NewMemo(func(design string, countValidCombinations func(string) mathx.Number) mathx.Number {
+		var combinations mathx.Number
+		if len(design) == 0 {
+			return combinations
+		}
 
-	var combinations int
-	for _, pattern := range towelMap[firstLetter] {
-		if strings.HasPrefix(design, pattern) {
-			if len(design) == len(pattern) {
-				combinations++
+		for _, pattern := range towelMap[rune(design[0])] {
+			if strings.HasPrefix(design, pattern) {
+				if len(design) == len(pattern) {
+					combinations = combinations.Add(mathx.NewNumber(1))
+				}
+				combinations = combinations.Add(countValidCombinations(design[len(pattern):]))
 			}
-			combinations += countValidCombinations(design[len(pattern):], towelMap, cache)
 		}
-	}
-	cache[design] = combinations
-	return combinations
+		return combinations
+	})
 }
 
-func part1(designs []string, towelMap map[rune][]string) {
-	cache := make(map[string]bool)
+func part1(ctx context.Context, in input) (int, error) {
+	checkDesign := newDesignCheck(in.towelMap)
 
 	tot := 0
-	for _, design := range designs {
-		if checkDesign(design, towelMap, cache) {
+	for _, design := range in.designs {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if checkDesign.Get(design) {
 			tot++
 		}
 	}
-	fmt.Println("Part 1:", tot)
+	shared.ReportMemo(ctx, "checkDesign", checkDesign.Stats())
+	return tot, nil
 }
 
-func part2(designs []string, towelMap map[rune][]string) {
-	cache := make(map[string]int)
+func part2(ctx context.Context, in input) (*big.Int, error) {
+	countValidCombinations := newCombinationCount(in.towelMap)
 
-	tot := 0
-	for _, design := range designs {
-		tot += countValidCombinations(design, towelMap, cache)
+	var tot mathx.Number
+	for _, design := range in.designs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		tot = tot.Add(countValidCombinations.Get(design))
 	}
-	fmt.Println("Part 2:", tot)
+	shared.ReportMemo(ctx, "countValidCombinations", countValidCombinations.Stats())
+	return tot.BigInt(), nil
 }
 
-func Run() {
-	lines, err := shared.ReadFileByBlankLine("days/day19/input.txt")
+func parse(r io.Reader) (input, error) {
+	lines, err := shared.ReadByBlankLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
-	towelMap, designs := parseInput(lines)
 
-	part1(designs, towelMap)
-	part2(designs, towelMap)
+	return parseInput(lines), nil
+}
+
+func init() {
+	solver.Register(19, solver.New(parse, part1, part2))
 }
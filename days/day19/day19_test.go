@@ -0,0 +1,79 @@
+package day19
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `r, wr, b, g, bwu, rb, gb, br
+
+brwrr
+bggr
+gbbr
+rrbgbr
+ubwu
+bwurrg
+brgr
+bbrgwb`
+
+func TestPart1(t *testing.T) {
+	solvertest.Part(t, parse, part1, example, 6)
+}
+
+func TestPart2(t *testing.T) {
+	solvertest.Part(t, parse, part2, example, big.NewInt(16))
+}
+
+func TestDesigns(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	tests := []struct {
+		design       string
+		possible     bool
+		combinations int
+	}{
+		{design: "brwrr", possible: true, combinations: 2},
+		{design: "bggr", possible: true, combinations: 1},
+		{design: "gbbr", possible: true, combinations: 4},
+		{design: "rrbgbr", possible: true, combinations: 6},
+		{design: "ubwu", possible: false, combinations: 0},
+		{design: "bwurrg", possible: true, combinations: 1},
+		{design: "brgr", possible: true, combinations: 2},
+		{design: "bbrgwb", possible: false, combinations: 0},
+	}
+
+	for _, tt := range tests {
+		if got := newDesignCheck(in.towelMap).Get(tt.design); got != tt.possible {
+			t.Errorf("checkDesign(%q) = %v, want %v", tt.design, got, tt.possible)
+		}
+		if got := newCombinationCount(in.towelMap).Get(tt.design); got.Cmp(mathx.NewNumber(tt.combinations)) != 0 {
+			t.Errorf("countValidCombinations(%q) = %v, want %d", tt.design, got, tt.combinations)
+		}
+	}
+}
+
+func TestCombinationCountBeyondInt(t *testing.T) {
+	// With towels r and rr, a design of n r's can be built in fib(n+1) ways, which overflows an int at n = 92.
+	towelMap := parseTowelPatterns([]string{"r", "rr"})
+	got := newCombinationCount(towelMap).Get(strings.Repeat("r", 100))
+
+	if want := "573147844013817084101"; got.String() != want {
+		t.Errorf("countValidCombinations(r×100) = %v, want %s", got, want)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
@@ -1,37 +1,43 @@
 package day20
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"errors"
+	"io"
 
 	"aoc2024/shared"
+	"aoc2024/shared/graph"
+	"aoc2024/shared/solver"
 )
 
-const minTimeSave = 100
-
-func getPath(grid shared.Grid[rune], startingPoint shared.Point, goal shared.Point) (map[shared.Point]int, []shared.Point) {
-	var path []shared.Point
-	pathMap := make(map[shared.Point]int)
+type input struct {
+	grid          shared.Grid[rune]
+	startingPoint shared.Point
+	goal          shared.Point
+}
 
-	curr := startingPoint
-	for i := 0; ; i++ {
-		pathMap[curr] = i
-		path = append(path, curr)
+const (
+	minTimeSave     = 100
+	maxCheatSeconds = 20
+)
 
-		if curr == goal {
-			break
-		}
+func getPath(ctx context.Context, grid shared.Grid[rune], startingPoint shared.Point, goal shared.Point) (map[shared.Point]int, []shared.Point, error) {
+	isGoal := func(p shared.Point) bool { return p == goal }
+	result, err := graph.BFS(ctx, graph.GridNeighbors(grid, '#'), startingPoint, isGoal)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		for _, neighbor := range curr.CardinalNeighbors() {
-			_, seen := pathMap[neighbor]
-			if seen || !grid.Contains(neighbor) || grid.Get(neighbor) == '#' {
-				continue
-			}
-			curr = neighbor
-		}
+	if !result.Found {
+		return nil, nil, errors.New("track does not reach the goal")
 	}
 
-	return pathMap, path
+	path := result.Path()
+	pathMap := make(map[shared.Point]int, len(path))
+	for i, p := range path {
+		pathMap[p] = i
+	}
+	return pathMap, path, nil
 }
 
 func getCheatSpots(wall shared.Point, grid shared.Grid[rune]) []shared.Point {
@@ -46,7 +52,7 @@ func getCheatSpots(wall shared.Point, grid shared.Grid[rune]) []shared.Point {
 	return cheatSpots
 }
 
-func part1(grid shared.Grid[rune], path map[shared.Point]int) {
+func countWallCheats(grid shared.Grid[rune], path map[shared.Point]int, minSave int) int {
 	cheatCounts := 0
 
 	for y, row := range grid.Rows() {
@@ -61,46 +67,68 @@ func part1(grid shared.Grid[rune], path map[shared.Point]int) {
 				for _, cheatEnd := range cheatSpots {
 					timeSave := path[cheatEnd] - path[cheatStart] - 2
 
-					if timeSave >= minTimeSave {
+					if timeSave >= minSave {
 						cheatCounts++
 					}
 				}
 			}
 		}
 	}
-	fmt.Println("Part 1:", cheatCounts)
+	return cheatCounts
 }
 
-func part2(path []shared.Point) {
-	lastCandidate := len(path) - 1 - minTimeSave
+func countCheats(ctx context.Context, path []shared.Point, maxCheat int, minSave int) (int, error) {
+	lastCandidate := len(path) - 1 - minSave
+	if lastCandidate < 0 {
+		return 0, nil
+	}
+
 	cheatCount := 0
 	for i, cheatStart := range path[:lastCandidate] {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		for j, cheatEnd := range path[i:] {
 			distance := shared.ManhattanDistance(cheatStart, cheatEnd)
-			if distance > 20 {
+			if distance > maxCheat {
 				continue
 			}
 
 			timeSave := j - distance
-			if timeSave >= minTimeSave {
+			if timeSave >= minSave {
 				cheatCount++
 			}
 		}
 	}
-	fmt.Println("Part 2:", cheatCount)
+	return cheatCount, nil
 }
 
-func Run() {
-	grid, startingPoint, goal, err := shared.ReadFileToRuneGridWithStartingPointAndGoal(
-		"days/day20/input.txt", 'S', 'E',
-	)
+func part1(ctx context.Context, in input) (int, error) {
+	pathMap, _, err := getPath(ctx, in.grid, in.startingPoint, in.goal)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return 0, err
 	}
+	return countWallCheats(in.grid, pathMap, minTimeSave), nil
+}
 
-	pathMap, path := getPath(grid, startingPoint, goal)
+func part2(ctx context.Context, in input) (int, error) {
+	_, path, err := getPath(ctx, in.grid, in.startingPoint, in.goal)
+	if err != nil {
+		return 0, err
+	}
+	return countCheats(ctx, path, maxCheatSeconds, minTimeSave)
+}
+
+func parse(r io.Reader) (input, error) {
+	grid, startingPoint, goal, err := shared.ReadToRuneGridWithStartingPointAndGoal(r, 'S', 'E')
+	if err != nil {
+		return input{}, err
+	}
+
+	return input{grid: grid, startingPoint: startingPoint, goal: goal}, nil
+}
 
-	part1(grid, pathMap)
-	part2(path)
+func init() {
+	solver.Register(20, solver.New(parse, part1, part2))
 }
@@ -0,0 +1,112 @@
+package day20
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `###############
+#...#...#.....#
+#.#.#.#.#.###.#
+#S#...#.#.#...#
+#######.#.#.###
+#######.#.#...#
+#######.#.###.#
+###..E#...#...#
+###.#######.###
+#...###...#...#
+#.#####.#.###.#
+#.#...#.#.#...#
+#.#.#.#.#.#.###
+#...#...#...###
+###############`
+
+func TestGetPath(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	pathMap, path, err := getPath(context.Background(), in.grid, in.startingPoint, in.goal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(path) != 85 {
+		t.Errorf("path has %d points, want 85", len(path))
+	}
+	if pathMap[in.goal] != 84 {
+		t.Errorf("goal reached after %d picoseconds, want 84", pathMap[in.goal])
+	}
+}
+
+func TestCountWallCheats(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+	pathMap, _, err := getPath(context.Background(), in.grid, in.startingPoint, in.goal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		minSave int
+		want    int
+	}{
+		{minSave: 2, want: 44},
+		{minSave: 20, want: 5},
+		{minSave: 64, want: 1},
+		{minSave: 65, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := countWallCheats(in.grid, pathMap, tt.minSave); got != tt.want {
+			t.Errorf("cheats saving at least %d = %d, want %d", tt.minSave, got, tt.want)
+		}
+	}
+}
+
+func TestCountCheats(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+	_, path, err := getPath(context.Background(), in.grid, in.startingPoint, in.goal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		maxCheat int
+		minSave  int
+		want     int
+	}{
+		{maxCheat: 2, minSave: 20, want: 5},
+		{maxCheat: 20, minSave: 50, want: 285},
+		{maxCheat: 20, minSave: 76, want: 3},
+		{maxCheat: 20, minSave: 100, want: 0},
+	}
+
+	for _, tt := range tests {
+		got, err := countCheats(context.Background(), path, tt.maxCheat, tt.minSave)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("cheats of up to %d saving at least %d = %d, want %d", tt.maxCheat, tt.minSave, got, tt.want)
+		}
+	}
+}
+
+func TestGetPathDeadEnd(t *testing.T) {
+	in := solvertest.Parse(t, parse, "#####\n#S.##\n###E#\n#####")
+
+	if _, _, err := getPath(context.Background(), in.grid, in.startingPoint, in.goal); err == nil {
+		t.Error("expected an error for a track that does not reach the goal")
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
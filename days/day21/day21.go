@@ -1,12 +1,15 @@
 package day21
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"math/big"
 	"strconv"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver"
 )
 
 var (
@@ -107,84 +110,52 @@ func findShortestSequence(start rune, end rune) string {
 	return sb.String()
 }
 
-func generateCacheKey(code string, depth int) string {
-	return fmt.Sprintf("%s|%d", code, depth)
+type sequence struct {
+	code  string
+	depth int
 }
 
-func getLength(code string, depth int, cache map[string]int) int {
-	cacheKey := generateCacheKey(code, depth)
-
-	if val, ok := cache[cacheKey]; ok {
-		return val
-	}
+// newSequenceLengths memoizes the number of presses needed to type a code through depth robots. The
+// lengths grow exponentially with the depth, so they are Numbers that cannot overflow.
+func newSequenceLengths() *shared.Memo[sequence, mathx.Number] {
+	return shared.NewMemo(func(seq sequence, getLength func(sequence) mathx.Number) mathx.Number {
+		if seq.depth == 0 {
+			return mathx.NewNumber(len(seq.code))
+		}
 
-	var length int
-	if depth == 0 {
-		length = len(code)
-		cache[cacheKey] = length
+		var length mathx.Number
+		start := initialKey
+		for _, char := range seq.code {
+			length = length.Add(getLength(sequence{code: findShortestSequence(start, char), depth: seq.depth - 1}))
+			start = char
+		}
 		return length
-	}
-
-	start := initialKey
-	for _, char := range code {
-		seq := findShortestSequence(start, char)
-		length += getLength(seq, depth-1, cache)
-		start = char
-	}
-
-	cache[cacheKey] = length
-	return length
+	})
 }
 
-func complexitySum(codes []string, depth int, cache map[string]int) (int, error) {
-	total := 0
+func complexitySum(ctx context.Context, codes []string, depth int) (*big.Int, error) {
+	lengths := newSequenceLengths()
+	var total mathx.Number
 	for _, code := range codes {
 		numVal, err := getNumericValue(code)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		total += getLength(code, depth, cache) * numVal
+		total = total.Add(lengths.Get(sequence{code: code, depth: depth}).Mul(mathx.NewNumber(numVal)))
 	}
-	return total, nil
+	shared.ReportMemo(ctx, fmt.Sprintf("sequence lengths (depth %d)", depth), lengths.Stats())
+	return total.BigInt(), nil
 }
 
-func part1(codes []string, cache map[string]int) error {
-	tot, err := complexitySum(codes, 3, cache)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("Part 1:", tot)
-	return nil
+func part1(ctx context.Context, codes []string) (*big.Int, error) {
+	return complexitySum(ctx, codes, 3)
 }
 
-func part2(codes []string, cache map[string]int) error {
-	tot, err := complexitySum(codes, 26, cache)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("Part 2:", tot)
-	return nil
+// part2 types through 26 keypads, or as many as the depth parameter says.
+func part2(ctx context.Context, codes []string) (*big.Int, error) {
+	return complexitySum(ctx, codes, solver.IntParam(ctx, "depth", 26))
 }
 
-func Run() {
-	codes, err := shared.ReadFileByLine("days/day21/input.txt")
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-
-	cache := make(map[string]int)
-
-	err = part1(codes, cache)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-	err = part2(codes, cache)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+func init() {
+	solver.Register(21, solver.New(shared.ReadByLine, part1, part2))
 }
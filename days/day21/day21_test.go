@@ -0,0 +1,115 @@
+package day21
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/mathx"
+	"aoc2024/shared/solver"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `029A
+980A
+179A
+456A
+379A`
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name string
+		part func(context.Context, []string) (*big.Int, error)
+		want *big.Int
+	}{
+		{name: "part 1", part: part1, want: big.NewInt(126384)},
+		{name: "part 2", part: part2, want: big.NewInt(154115708116294)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, shared.ReadByLine, tt.part, example, tt.want)
+		})
+	}
+}
+
+func TestFindShortestSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		start rune
+		end   rune
+		want  string
+	}{
+		{name: "numeric same key", start: 'A', end: 'A', want: "A"},
+		{name: "numeric left", start: 'A', end: '0', want: "<A"},
+		{name: "numeric up", start: '0', end: '2', want: "^A"},
+		{name: "numeric right before up", start: '2', end: '9', want: "^^>A"},
+		{name: "numeric down", start: '9', end: 'A', want: "vvvA"},
+		{name: "numeric avoids gap going up", start: 'A', end: '1', want: "^<<A"},
+		{name: "numeric avoids gap going down", start: '7', end: '0', want: ">vvvA"},
+		{name: "directional avoids gap", start: 'A', end: '<', want: "v<<A"},
+		{name: "directional leaves gap", start: '<', end: '^', want: ">^A"},
+		{name: "directional diagonal", start: 'v', end: 'A', want: "^>A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findShortestSequence(tt.start, tt.end); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequenceLengths(t *testing.T) {
+	tests := []struct {
+		code  string
+		depth int
+		want  int
+	}{
+		{code: "029A", depth: 0, want: 4},
+		{code: "029A", depth: 1, want: len("<A^A>^^AvvvA")},
+		{code: "029A", depth: 2, want: len("v<<A>>^A<A>AvA<^AA>A<vAAA>^A")},
+		{code: "029A", depth: 3, want: 68},
+		{code: "379A", depth: 3, want: 64},
+	}
+
+	for _, tt := range tests {
+		if got := newSequenceLengths().Get(sequence{code: tt.code, depth: tt.depth}); got.Cmp(mathx.NewNumber(tt.want)) != 0 {
+			t.Errorf("length of %q at depth %d = %v, want %d", tt.code, tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestPart2DepthParam(t *testing.T) {
+	codes := solvertest.Parse(t, shared.ReadByLine, example)
+
+	got, err := part2(solver.WithParam(context.Background(), "depth", 3), codes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Int64() != 126384 {
+		t.Errorf("got %v, want 126384", got)
+	}
+
+	deep, err := part2(solver.WithParam(context.Background(), "depth", 60), codes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deep.IsInt64() {
+		t.Errorf("the complexity at depth 60 is %v, which should not fit in an int", deep)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, shared.ReadByLine, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadByLine, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadByLine, part2, example)
+}
@@ -1,10 +1,11 @@
 package day22
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"slices"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 func generateNextSecretNumber(num int) int {
@@ -18,63 +19,71 @@ func getPrice(num int) int {
 	return num % 10
 }
 
-func updatePrices(prices map[string]int, num int) {
-	seen := shared.NewSet[string]()
+// A sequence of four price changes, each between -9 and 9, is keyed as a base-19 number with the oldest
+// change as the highest digit.
+const (
+	sequenceLength = 4
+	changeValues   = 19
+	sequenceKeys   = changeValues * changeValues * changeValues * changeValues
+	oldestWeight   = sequenceKeys / changeValues
+)
+
+// updatePrices adds the price this buyer pays at the first occurrence of each sequence of four changes.
+// seen is cleared and reused between buyers.
+func updatePrices(prices []int, seen *shared.BitSet, num int) {
+	seen.Clear()
 	price := getPrice(num)
-	queue := shared.NewFIFOQueue[int](4)
+	changes := shared.NewSlidingWindow[int](sequenceLength)
+	key := 0
 	for range 2000 {
 		num = generateNextSecretNumber(num)
 		newPrice := getPrice(num)
-		queue.Enqueue(newPrice - price)
+		change := newPrice - price
 		price = newPrice
 
-		seqKey := queue.String()
-		if !seen.Contains(seqKey) {
-			seen.Add(seqKey)
-			prices[seqKey] += newPrice
+		// Rolling the key along with the window keeps every step constant time.
+		if dropped, ok := changes.Push(change); ok {
+			key -= (dropped + 9) * oldestWeight
 		}
+		key = key*changeValues + change + 9
 
-	}
-}
-
-func findBest(prices map[string]int) int {
-	best := 0
-	for _, price := range prices {
-		if price > best {
-			best = price
+		if !changes.Full() || seen.Contains(key) {
+			continue
 		}
+		seen.Add(key)
+		prices[key] += newPrice
 	}
-	return best
 }
 
-func part1(numbers []int) {
+func part1(ctx context.Context, numbers []int) (int, error) {
 	tot := 0
 	for _, num := range numbers {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		for range 2000 {
 			num = generateNextSecretNumber(num)
 		}
 		tot += num
 	}
-	fmt.Println("Part 1:", tot)
+	return tot, nil
 }
 
-func part2(numbers []int) {
-	prices := make(map[string]int)
+func part2(ctx context.Context, numbers []int) (int, error) {
+	prices := make([]int, sequenceKeys)
+	seen := shared.NewBitSet(sequenceKeys)
 	for _, num := range numbers {
-		updatePrices(prices, num)
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		updatePrices(prices, seen, num)
 	}
 
-	best := findBest(prices)
-	fmt.Println("Part 2:", best)
+	return slices.Max(prices), nil
 }
 
-func Run() {
-	numbers, err := shared.ReadFileByLineToInt("days/day22/input.txt")
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-
-	part1(numbers)
-	part2(numbers)
+func init() {
+	solver.Register(22, solver.New(shared.ReadByLineToInt, part1, part2))
 }
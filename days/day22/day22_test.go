@@ -0,0 +1,53 @@
+package day22
+
+import (
+	"context"
+	"testing"
+
+	"aoc2024/shared"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = "1\n10\n100\n2024"
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		part  func(context.Context, []int) (int, error)
+		want  int
+	}{
+		{name: "part 1", input: example, part: part1, want: 37327623},
+		{name: "part 2", input: "1\n2\n3\n2024", part: part2, want: 23},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, shared.ReadByLineToInt, tt.part, tt.input, tt.want)
+		})
+	}
+}
+
+func TestGenerateNextSecretNumber(t *testing.T) {
+	want := []int{15887950, 16495136, 527345, 704524, 1553684, 12683156, 11100544, 12249484, 7753432, 5908254}
+
+	num := 123
+	for i, next := range want {
+		num = generateNextSecretNumber(num)
+		if num != next {
+			t.Fatalf("secret number %d = %d, want %d", i+1, num, next)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, shared.ReadByLineToInt, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadByLineToInt, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, shared.ReadByLineToInt, part2, example)
+}
@@ -1,12 +1,14 @@
 package day23
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
+	"slices"
 	"sort"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 func parseConnection(connectionRaw string) (string, string) {
@@ -31,22 +33,15 @@ func parseGraph(connectionsRaw []string) map[string]*shared.Set[string] {
 	return graph
 }
 
-func findChains(graph map[string]*shared.Set[string], start string, goal string, length int) [][]string {
-	if length == 0 {
-		if start == goal {
-			return [][]string{{}}
+// trianglesWith returns every set of three computers, including node, that are all connected to each other.
+func trianglesWith(graph map[string]*shared.Set[string], node string) [][]string {
+	var triangles [][]string
+	for neighbor := range graph[node].All() {
+		for common := range graph[node].Intersection(graph[neighbor]).All() {
+			triangles = append(triangles, []string{node, neighbor, common})
 		}
-		return nil
 	}
-
-	var chains [][]string
-	for _, node := range graph[start].Items() {
-		subChains := findChains(graph, node, goal, length-1)
-		for _, subChain := range subChains {
-			chains = append(chains, append([]string{node}, subChain...))
-		}
-	}
-	return chains
+	return triangles
 }
 
 func allConnected(graph map[string]*shared.Set[string], nodes []string) bool {
@@ -72,9 +67,9 @@ func bestForNode(graph map[string]*shared.Set[string], node string, currentBest
 	}
 
 	for length > currentBest {
-		for _, combo := range shared.Combinations(nodes, length) {
+		for combo := range shared.Combinations(nodes, length) {
 			if allConnected(graph, combo) {
-				return append(combo, node)
+				return append(slices.Clone(combo), node)
 			}
 		}
 		length--
@@ -82,27 +77,34 @@ func bestForNode(graph map[string]*shared.Set[string], node string, currentBest
 	return nil
 }
 
-func part1(graph map[string]*shared.Set[string]) {
-	uniqueChains := shared.NewSet[string]()
+func part1(ctx context.Context, graph map[string]*shared.Set[string]) (int, error) {
+	triangles := shared.NewSet[string]()
 	for node := range graph {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		if node[0] != 't' {
 			continue
 		}
 
-		chains := findChains(graph, node, node, 3)
-		for _, chain := range chains {
-			sort.Strings(chain)
-			uniqueChains.Add(strings.Join(chain, ""))
+		for _, triangle := range trianglesWith(graph, node) {
+			sort.Strings(triangle)
+			triangles.Add(strings.Join(triangle, ","))
 		}
 	}
-	fmt.Println("Part 1:", uniqueChains.Size())
+	return triangles.Size(), nil
 }
 
-func part2(graph map[string]*shared.Set[string]) {
+func part2(ctx context.Context, graph map[string]*shared.Set[string]) (string, error) {
 	var best []string
 	var lengthToBeat int
 
 	for node := range graph {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
 		bestCombo := bestForNode(graph, node, lengthToBeat)
 		if bestCombo != nil && len(bestCombo) > lengthToBeat {
 			best = bestCombo
@@ -110,17 +112,18 @@ func part2(graph map[string]*shared.Set[string]) {
 		}
 	}
 	sort.Strings(best)
-	fmt.Println("Part 2:", strings.Join(best, ","))
+	return strings.Join(best, ","), nil
 }
 
-func Run() {
-	connectionsRaw, err := shared.ReadFileByLine("days/day23/input.txt")
+func parse(r io.Reader) (map[string]*shared.Set[string], error) {
+	connectionsRaw, err := shared.ReadByLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return nil, err
 	}
 
-	graph := parseGraph(connectionsRaw)
-	part1(graph)
-	part2(graph)
+	return parseGraph(connectionsRaw), nil
+}
+
+func init() {
+	solver.Register(23, solver.New(parse, part1, part2))
 }
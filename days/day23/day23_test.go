@@ -0,0 +1,79 @@
+package day23
+
+import (
+	"testing"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `kh-tc
+qp-kh
+de-cg
+ka-co
+yn-aq
+qp-ub
+cg-tb
+vc-aq
+tb-ka
+wh-tc
+yn-cg
+kh-ub
+ta-co
+de-co
+tc-td
+tb-wq
+wh-td
+ta-ka
+td-qp
+aq-cg
+wq-ub
+ub-vc
+de-ta
+wq-aq
+wq-vc
+wh-yn
+ka-de
+kh-ta
+co-tc
+wh-qp
+tb-vc
+td-yn`
+
+func TestPart1(t *testing.T) {
+	solvertest.Part(t, parse, part1, example, 7)
+}
+
+func TestPart2(t *testing.T) {
+	solvertest.Part(t, parse, part2, example, "co,de,ka,ta")
+}
+
+func TestAllConnected(t *testing.T) {
+	graph := solvertest.Parse(t, parse, example)
+
+	tests := []struct {
+		nodes []string
+		want  bool
+	}{
+		{nodes: []string{"co", "de", "ka", "ta"}, want: true},
+		{nodes: []string{"kh", "qp", "ub"}, want: true},
+		{nodes: []string{"co", "de", "ka", "tc"}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := allConnected(graph, tt.nodes); got != tt.want {
+			t.Errorf("allConnected(%v) = %v, want %v", tt.nodes, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPart(b, parse, part2, example)
+}
@@ -1,14 +1,17 @@
 package day24
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"maps"
 	"sort"
 	"strconv"
 	"strings"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
 type operation string
@@ -19,6 +22,11 @@ const (
 	xor = "XOR"
 )
 
+type input struct {
+	state        map[string]int
+	instructions map[string]instruction
+}
+
 type instruction struct {
 	firstKey  string
 	secondKey string
@@ -55,13 +63,13 @@ func parseInstructions(instructionsRaw []string) map[string]instruction {
 	return instructions
 }
 
-func parseInput(inputRaw [][]string) (map[string]int, map[string]instruction, error) {
+func parseInput(inputRaw [][]string) (input, error) {
 	state, err := parseState(inputRaw[0])
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing input: %w", err)
+		return input{}, fmt.Errorf("error parsing input: %w", err)
 	}
 	instructions := parseInstructions(inputRaw[1])
-	return state, instructions, nil
+	return input{state: state, instructions: instructions}, nil
 }
 
 func runInstruction(state map[string]int, instr instruction) error {
@@ -83,7 +91,7 @@ func runInstruction(state map[string]int, instr instruction) error {
 	return nil
 }
 
-func runInstructions(state map[string]int, instructions map[string]instruction) {
+func runInstructions(ctx context.Context, state map[string]int, instructions map[string]instruction) error {
 	var instr instruction
 
 	queue := make([]instruction, 0, len(instructions))
@@ -92,12 +100,17 @@ func runInstructions(state map[string]int, instructions map[string]instruction)
 	}
 
 	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		instr, queue = queue[0], queue[1:]
 		err := runInstruction(state, instr)
 		if err != nil {
 			queue = append(queue, instr)
 		}
 	}
+	return nil
 }
 
 func getIntegerFromBinary(state map[string]int, letter string) (int, error) {
@@ -239,28 +252,37 @@ func findWrongBitIndex(x int, y int, z int) int {
 	return -1
 }
 
-func part1(state map[string]int, instructions map[string]instruction) error {
-	runInstructions(state, instructions)
+func part1(ctx context.Context, in input) (int, error) {
+	state := maps.Clone(in.state)
+	err := runInstructions(ctx, state, in.instructions)
+	if err != nil {
+		return 0, fmt.Errorf("error running part 1: %w", err)
+	}
+
 	res, err := calculateResult(state)
 	if err != nil {
-		return fmt.Errorf("error running part 1: %w", err)
+		return 0, fmt.Errorf("error running part 1: %w", err)
 	}
-	fmt.Println("Part 1:", res)
-	return nil
+	return res, nil
 }
 
-func part2(state map[string]int, instructions map[string]instruction) error {
+func part2(ctx context.Context, in input) (string, error) {
+	state, instructions := maps.Clone(in.state), maps.Clone(in.instructions)
+
 	zSwaps := findZSwaps(instructions)
 	swapCandidates := findSwapCandidates(instructions)
 	matches := matchSwaps(zSwaps, swapCandidates, instructions)
 
 	resetState(state)
 	newInstructions := swapInstructions(instructions, matches)
-	runInstructions(state, newInstructions)
+	err := runInstructions(ctx, state, newInstructions)
+	if err != nil {
+		return "", fmt.Errorf("error running part 2: %w", err)
+	}
 
 	x, y, z, err := getXYZ(state)
 	if err != nil {
-		return fmt.Errorf("error running part 2: %w", err)
+		return "", fmt.Errorf("error running part 2: %w", err)
 	}
 
 	wrongBit := strconv.Itoa(findWrongBitIndex(x, y, z))
@@ -278,33 +300,18 @@ func part2(state map[string]int, instructions map[string]instruction) error {
 	}
 
 	sort.Strings(swaps)
-	joinedSwaps := strings.Join(swaps, ",")
-
-	fmt.Println("Part 2:", joinedSwaps)
-
-	return nil
+	return strings.Join(swaps, ","), nil
 }
 
-func Run() {
-	inputRaw, err := shared.ReadFileByBlankLine("days/day24/input.txt")
+func parse(r io.Reader) (input, error) {
+	inputRaw, err := shared.ReadByBlankLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-	state, instructions, err := parseInput(inputRaw)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
-	err = part1(state, instructions)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
-	err = part2(state, instructions)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
-	}
+	return parseInput(inputRaw)
+}
+
+func init() {
+	solver.Register(24, solver.New(parse, part1, part2))
 }
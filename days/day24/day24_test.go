@@ -0,0 +1,139 @@
+package day24
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aoc2024/shared/solver/solvertest"
+)
+
+const smallExample = `x00: 1
+x01: 1
+x02: 1
+y00: 0
+y01: 1
+y02: 0
+
+x00 AND y00 -> z00
+x01 XOR y01 -> z01
+x02 OR y02 -> z02`
+
+const largeExample = `x00: 1
+x01: 0
+x02: 1
+x03: 1
+x04: 0
+y00: 1
+y01: 1
+y02: 1
+y03: 1
+y04: 1
+
+ntg XOR fgs -> mjb
+y02 OR x01 -> tnw
+kwq OR kpj -> z05
+x00 OR x03 -> fst
+tgd XOR rvg -> z01
+vdt OR tnw -> bfw
+bfw AND frj -> z10
+ffh OR nrd -> bqk
+y00 AND y03 -> djm
+y03 OR y00 -> psh
+bqk OR frj -> z08
+tnw OR fst -> frj
+gnj AND tgd -> z11
+bfw XOR mjb -> z00
+x03 OR x00 -> vdt
+gnj AND wpb -> z02
+x04 AND y00 -> kjc
+djm OR pbm -> qhw
+nrd AND vdt -> hwm
+kjc AND fst -> rvg
+y04 OR y02 -> fgs
+y01 AND x02 -> pbm
+ntg OR kjc -> kwq
+psh XOR fgs -> tgd
+qhw XOR tgd -> z09
+pbm OR djm -> kpj
+x03 XOR y03 -> ffh
+x00 XOR y04 -> ntg
+bfw OR bqk -> z06
+nrd XOR fgs -> wpb
+frj XOR qhw -> z04
+bqk OR frj -> z07
+y03 OR x01 -> nrd
+hwm AND bqk -> z03
+tgd XOR rvg -> z12
+tnw OR pbm -> gnj`
+
+func TestPart1(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "small", input: smallExample, want: 4},
+		{name: "large", input: largeExample, want: 2024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvertest.Part(t, parse, part1, tt.input, tt.want)
+		})
+	}
+}
+
+func TestPart1DoesNotModifyInput(t *testing.T) {
+	in := solvertest.Parse(t, parse, smallExample)
+
+	if _, err := part1(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := in.state["z00"]; ok {
+		t.Errorf("part1 wrote wire values into the parsed state")
+	}
+}
+
+func TestPart1StopsOnMissingWire(t *testing.T) {
+	in := solvertest.Parse(t, parse, "x00: 1\n\nx00 AND w00 -> z00")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := part1(ctx, in); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestFindWrongBitIndex(t *testing.T) {
+	tests := []struct {
+		x    int
+		y    int
+		z    int
+		want int
+	}{
+		{x: 0b1011, y: 0b0001, z: 0b1100, want: -1},
+		{x: 0b1011, y: 0b0001, z: 0b1000, want: 2},
+		{x: 0b1011, y: 0b0001, z: 0b1110, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := findWrongBitIndex(tt.x, tt.y, tt.z); got != tt.want {
+			t.Errorf("findWrongBitIndex(%b, %b, %b) = %d, want %d", tt.x, tt.y, tt.z, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, largeExample)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, largeExample)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchPartInput(b, parse, part2)
+}
@@ -1,12 +1,18 @@
 package day25
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"io"
 
 	"aoc2024/shared"
+	"aoc2024/shared/solver"
 )
 
+type input struct {
+	keys  [][5]int
+	locks [][5]int
+}
+
 func parseInput(inputRaw [][]string) ([][5]int, [][5]int) {
 	var keys [][5]int
 	var locks [][5]int
@@ -47,26 +53,32 @@ func validCombo(key [5]int, lock [5]int) bool {
 	return true
 }
 
-func part1(keys [][5]int, locks [][5]int) {
+func part1(_ context.Context, in input) (int, error) {
 	tot := 0
-	for _, key := range keys {
-		for _, lock := range locks {
+	for _, key := range in.keys {
+		for _, lock := range in.locks {
 			if validCombo(key, lock) {
 				tot++
 			}
 		}
 	}
-	fmt.Println("Part 1:", tot)
+	return tot, nil
 }
 
-func Run() {
-	inputRaw, err := shared.ReadFileByBlankLine("days/day25/input.txt")
+func part2(_ context.Context, in input) (int, error) {
+	return 0, solver.ErrNoSolution
+}
+
+func parse(r io.Reader) (input, error) {
+	inputRaw, err := shared.ReadByBlankLine(r)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return
+		return input{}, err
 	}
 
 	keys, locks := parseInput(inputRaw)
+	return input{keys: keys, locks: locks}, nil
+}
 
-	part1(keys, locks)
+func init() {
+	solver.Register(25, solver.New(parse, part1, part2))
 }
@@ -0,0 +1,91 @@
+package day25
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aoc2024/shared/solver"
+	"aoc2024/shared/solver/solvertest"
+)
+
+const example = `#####
+.####
+.####
+.####
+.#.#.
+.#...
+.....
+
+#####
+##.##
+.#.##
+...##
+...#.
+...#.
+.....
+
+.....
+#....
+#....
+#...#
+#.#.#
+#.###
+#####
+
+.....
+.....
+#.#..
+###..
+###.#
+###.#
+#####
+
+.....
+.....
+.....
+#....
+#.#..
+#.#.#
+#####`
+
+func TestPart1(t *testing.T) {
+	solvertest.Part(t, parse, part1, example, 3)
+}
+
+func TestPart2HasNoSolution(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	if _, err := part2(context.Background(), in); !errors.Is(err, solver.ErrNoSolution) {
+		t.Errorf("got error %v, want %v", err, solver.ErrNoSolution)
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	in := solvertest.Parse(t, parse, example)
+
+	wantKeys := [][5]int{{0, 5, 3, 4, 3}, {1, 2, 0, 5, 3}}
+	wantLocks := [][5]int{{5, 0, 2, 1, 3}, {4, 3, 4, 0, 2}, {3, 0, 2, 0, 1}}
+
+	if len(in.keys) != len(wantKeys) || len(in.locks) != len(wantLocks) {
+		t.Fatalf("got %d keys and %d locks, want %d and %d", len(in.keys), len(in.locks), len(wantKeys), len(wantLocks))
+	}
+	for i := range wantKeys {
+		if in.keys[i] != wantKeys[i] {
+			t.Errorf("key %d = %v, want %v", i, in.keys[i], wantKeys[i])
+		}
+	}
+	for i := range wantLocks {
+		if in.locks[i] != wantLocks[i] {
+			t.Errorf("lock %d = %v, want %v", i, in.locks[i], wantLocks[i])
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchParse(b, parse, example)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchPart(b, parse, part1, example)
+}
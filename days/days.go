@@ -0,0 +1,30 @@
+// Package days registers the solver of every day with the solver registry.
+package days
+
+import (
+	_ "aoc2024/days/day01"
+	_ "aoc2024/days/day02"
+	_ "aoc2024/days/day03"
+	_ "aoc2024/days/day04"
+	_ "aoc2024/days/day05"
+	_ "aoc2024/days/day06"
+	_ "aoc2024/days/day07"
+	_ "aoc2024/days/day08"
+	_ "aoc2024/days/day09"
+	_ "aoc2024/days/day10"
+	_ "aoc2024/days/day11"
+	_ "aoc2024/days/day12"
+	_ "aoc2024/days/day13"
+	_ "aoc2024/days/day14"
+	_ "aoc2024/days/day15"
+	_ "aoc2024/days/day16"
+	_ "aoc2024/days/day17"
+	_ "aoc2024/days/day18"
+	_ "aoc2024/days/day19"
+	_ "aoc2024/days/day20"
+	_ "aoc2024/days/day21"
+	_ "aoc2024/days/day22"
+	_ "aoc2024/days/day23"
+	_ "aoc2024/days/day24"
+	_ "aoc2024/days/day25"
+)
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+const (
+	exitOK = iota
+	exitFailure
+	exitUsage
+	exitInputMissing
+	exitParseFailure
+	exitSolverFailure
+	exitTimeout
+)
+
+var (
+	errTimeout = errors.New("timed out")
+	errSkipped = errors.New("skipped")
+)
+
+type stage int
+
+const (
+	stageInput stage = iota
+	stageParse
+	stagePart1
+	stagePart2
+)
+
+type dayError struct {
+	day   int
+	stage stage
+	err   error
+}
+
+func (e *dayError) Error() string {
+	switch e.stage {
+	case stageInput:
+		return fmt.Sprintf("day %d: reading input: %v", e.day, e.err)
+	case stageParse:
+		return fmt.Sprintf("day %d: parsing input: %v", e.day, e.err)
+	default:
+		return fmt.Sprintf("day %d part %d: %v", e.day, e.stage-stagePart1+1, e.err)
+	}
+}
+
+func (e *dayError) Unwrap() error {
+	return e.err
+}
+
+// exitCode maps an error to the process exit code that tells apart missing input, parse failures and solver failures.
+func exitCode(err error) int {
+	var de *dayError
+	if !errors.As(err, &de) {
+		return exitFailure
+	}
+
+	switch {
+	case de.stage == stageInput && errors.Is(de.err, fs.ErrNotExist):
+		return exitInputMissing
+	case de.stage == stageInput:
+		return exitFailure
+	case errors.Is(de.err, errTimeout), errors.Is(de.err, context.DeadlineExceeded):
+		return exitTimeout
+	case de.stage == stageParse:
+		return exitParseFailure
+	default:
+		return exitSolverFailure
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "plain error", err: errors.New("boom"), want: exitFailure},
+		{name: "missing input", err: &dayError{day: 1, stage: stageInput, err: fs.ErrNotExist}, want: exitInputMissing},
+		{
+			name: "wrapped missing input",
+			err:  fmt.Errorf("running: %w", &dayError{day: 1, stage: stageInput, err: &fs.PathError{Op: "open", Err: fs.ErrNotExist}}),
+			want: exitInputMissing,
+		},
+		{name: "unreadable input", err: &dayError{day: 1, stage: stageInput, err: fs.ErrPermission}, want: exitFailure},
+		{name: "parse failure", err: &dayError{day: 2, stage: stageParse, err: errors.New("bad line")}, want: exitParseFailure},
+		{name: "part failure", err: &dayError{day: 3, stage: stagePart1, err: errors.New("no path")}, want: exitSolverFailure},
+		{name: "timeout", err: &dayError{day: 4, stage: stagePart2, err: fmt.Errorf("%w after 1s", errTimeout)}, want: exitTimeout},
+		{
+			name: "skipped after the deadline",
+			err:  &dayError{day: 4, stage: stagePart2, err: fmt.Errorf("%w: %w", errSkipped, context.DeadlineExceeded)},
+			want: exitTimeout,
+		},
+		{name: "parse timeout", err: &dayError{day: 4, stage: stageParse, err: fmt.Errorf("%w after 1s", errTimeout)}, want: exitTimeout},
+		{name: "cancelled", err: &dayError{day: 5, stage: stagePart1, err: context.Canceled}, want: exitSolverFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  *dayError
+		want string
+	}{
+		{err: &dayError{day: 1, stage: stageInput, err: errors.New("boom")}, want: "day 1: reading input: boom"},
+		{err: &dayError{day: 2, stage: stageParse, err: errors.New("boom")}, want: "day 2: parsing input: boom"},
+		{err: &dayError{day: 3, stage: stagePart2, err: errors.New("boom")}, want: "day 3 part 2: boom"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRunDayExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	garbage := filepath.Join(dir, "garbage.txt")
+	if err := os.WriteFile(garbage, []byte("not a computer"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "missing input", path: filepath.Join(dir, "missing.txt"), want: exitInputMissing},
+		{name: "parse failure", path: garbage, want: exitParseFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := runDay(context.Background(), 17, tt.path).errors()
+			if len(errs) != 1 {
+				t.Fatalf("got errors %v, want one", errs)
+			}
+			if got := exitCode(errs[0]); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", errs[0], got, tt.want)
+			}
+		})
+	}
+}
@@ -1,93 +1,252 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
 
-	"aoc2024/days/day01"
-	"aoc2024/days/day02"
-	"aoc2024/days/day03"
-	"aoc2024/days/day04"
-	"aoc2024/days/day05"
-	"aoc2024/days/day06"
-	"aoc2024/days/day07"
-	"aoc2024/days/day08"
-	"aoc2024/days/day09"
-	"aoc2024/days/day10"
-	"aoc2024/days/day11"
-	"aoc2024/days/day12"
-	"aoc2024/days/day13"
-	"aoc2024/days/day14"
-	"aoc2024/days/day15"
-	"aoc2024/days/day16"
-	"aoc2024/days/day17"
-	"aoc2024/days/day18"
-	"aoc2024/days/day19"
-	"aoc2024/days/day20"
-	"aoc2024/days/day21"
-	"aoc2024/days/day22"
-	"aoc2024/days/day23"
-	"aoc2024/days/day24"
-	"aoc2024/days/day25"
+	_ "aoc2024/days"
+	"aoc2024/shared"
+	"aoc2024/shared/render"
+	"aoc2024/shared/solver"
 )
 
-func main() {
-	day := flag.Int("day", 0, "Specify day number to run (1-25)")
-	flag.Parse()
+type options struct {
+	daySpec     string
+	path        string
+	answersFile string
+	verify      bool
+	record      bool
+	format      string
+	benchRuns   int
+	timeout     time.Duration
+	verbose     bool
+	render      string
+	blinks      int
+	depth       int
+	params      map[string]int
+}
 
-	switch *day {
-	case 1:
-		day01.Run()
-	case 2:
-		day02.Run()
-	case 3:
-		day03.Run()
-	case 4:
-		day04.Run()
-	case 5:
-		day05.Run()
-	case 6:
-		day06.Run()
-	case 7:
-		day07.Run()
-	case 8:
-		day08.Run()
-	case 9:
-		day09.Run()
-	case 10:
-		day10.Run()
-	case 11:
-		day11.Run()
-	case 12:
-		day12.Run()
-	case 13:
-		day13.Run()
-	case 14:
-		day14.Run()
-	case 15:
-		day15.Run()
-	case 16:
-		day16.Run()
-	case 17:
-		day17.Run()
-	case 18:
-		day18.Run()
-	case 19:
-		day19.Run()
-	case 20:
-		day20.Run()
-	case 21:
-		day21.Run()
-	case 22:
-		day22.Run()
-	case 23:
-		day23.Run()
-	case 24:
-		day24.Run()
-	case 25:
-		day25.Run()
+func printPart(w io.Writer, partNr int, part partReport) {
+	switch {
+	case errors.Is(part.err, solver.ErrNoSolution):
+	case part.err != nil:
+		fmt.Fprintf(w, "Part %d: error\n", partNr)
 	default:
+		fmt.Fprintf(w, "Part %d: %v\n", partNr, part.answer)
+	}
+}
+
+func usageError(format string, args ...any) int {
+	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
+	return exitUsage
+}
+
+// reportErrors prints every error and returns the exit code of the first one.
+func reportErrors(errs []error) int {
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	if len(errs) == 0 {
+		return exitOK
+	}
+	return exitCode(errs[0])
+}
+
+func dayPaths(opts options, day int) (string, string) {
+	path := opts.path
+	if path == "" {
+		path = inputPath(day)
+	}
+
+	answers := opts.answersFile
+	if answers == "" {
+		answers = answersPath(path)
+	}
+	return path, answers
+}
+
+// dayContext returns the context a day runs in, carrying the puzzle parameters given on the command line
+// and limited to the -timeout budget when one is set.
+func dayContext(ctx context.Context, opts options) (context.Context, context.CancelFunc) {
+	for name, value := range opts.params {
+		ctx = solver.WithParam(ctx, name, value)
+	}
+	if opts.timeout > 0 {
+		return context.WithTimeout(ctx, opts.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func runBench(ctx context.Context, opts options, days []int) int {
+	var (
+		benches []dayBench
+		errs    []error
+	)
+	for _, day := range days {
+		path, _ := dayPaths(opts, day)
+		dayCtx, cancel := dayContext(ctx, opts)
+		bench, err := benchDay(dayCtx, day, path, opts.benchRuns)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		benches = append(benches, bench)
+	}
+
+	if err := printBenchTable(os.Stdout, benches); err != nil {
+		errs = append(errs, err)
+	}
+	return reportErrors(errs)
+}
+
+func runSolutions(ctx context.Context, opts options, days []int) int {
+	var (
+		reports []dayReport
+		checks  []check
+		errs    []error
+	)
+
+	var pictures *pictureWriter
+	if opts.render != "" {
+		pictures = newPictureWriter(opts.render)
+	}
+
+	for _, day := range days {
+		path, answersPath := dayPaths(opts, day)
+
+		dayCtx, cancel := dayContext(ctx, opts)
+		if opts.verbose {
+			dayCtx = shared.WithMemoReporter(dayCtx, func(name string, stats shared.MemoStats) {
+				fmt.Fprintf(os.Stderr, "day %d: %s memo: %v\n", day, name, stats)
+			})
+		}
+		if pictures != nil {
+			dayCtx = render.WithReporter(dayCtx, pictures.reporter(day))
+		}
+		report := runDay(dayCtx, day, path)
+		cancel()
+		reports = append(reports, report)
+		dayErrs := report.errors()
+		errs = append(errs, dayErrs...)
+
+		if opts.verify {
+			expected, err := loadAnswers(answersPath)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("day %d: %w", day, err))
+			}
+			checks = append(checks, verifyDay(report, expected)...)
+		}
+
+		if opts.record && len(dayErrs) == 0 {
+			err := saveAnswers(answersPath, report)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("day %d: %w", day, err))
+			}
+		}
+	}
+
+	if pictures != nil {
+		errs = append(errs, pictures.errors()...)
+	}
+
+	var err error
+	if opts.verify {
+		err = printChecks(os.Stdout, checks)
+	} else {
+		err = printReports(os.Stdout, opts.format, reports)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if code := reportErrors(errs); code != exitOK {
+		return code
+	}
+	for _, c := range checks {
+		if c.failed() {
+			return exitFailure
+		}
+	}
+	return exitOK
+}
+
+func run() int {
+	var opts options
+	flag.StringVar(&opts.daySpec, "day", "", "Day to run (1-25), a range such as 10-15, or all")
+	flag.StringVar(&opts.path, "input", "", "Path to the puzzle input, or - for stdin (default days/dayNN/input.txt)")
+	flag.StringVar(&opts.answersFile, "answers", "", "Path to the recorded answers (default answers.json next to the input)")
+	flag.BoolVar(&opts.verify, "verify", false, "Check the answers against the recorded answers")
+	flag.BoolVar(&opts.record, "record", false, "Record the answers as the expected answers")
+	flag.StringVar(&opts.format, "format", formatText, "Output format: text, json or csv")
+	flag.IntVar(&opts.benchRuns, "bench", 0, "Run each solution this many times and report timing statistics")
+	flag.DurationVar(&opts.timeout, "timeout", 0, "Time budget for each day, such as 500ms or 10s (default no limit)")
+	flag.BoolVar(&opts.verbose, "verbose", false, "Print memoization statistics to stderr")
+	flag.StringVar(&opts.render, "render", "", "Draw the grids of days 12, 14 and 16 as png or svg files, or as ansi text on stderr")
+	flag.IntVar(&opts.blinks, "blinks", 75, "Number of blinks for day 11 part 2")
+	flag.IntVar(&opts.depth, "depth", 26, "Number of keypads to type through for day 21 part 2")
+	flag.Parse()
+
+	opts.params = make(map[string]int)
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "blinks":
+			opts.params[f.Name] = opts.blinks
+		case "depth":
+			opts.params[f.Name] = opts.depth
+		}
+	})
+
+	if opts.daySpec == "" {
 		fmt.Println("Please specify a valid day (1-25).")
+		return exitUsage
+	}
+
+	days, err := parseDays(opts.daySpec)
+	if err != nil {
+		return usageError("%v", err)
+	}
 
+	switch {
+	case len(days) > 1 && (opts.path != "" || opts.answersFile != ""):
+		return usageError("-input and -answers can only be used with a single day")
+	case opts.path == stdinPath && opts.answersFile == "" && (opts.verify || opts.record):
+		return usageError("-answers is required when reading the input from stdin")
+	case !validFormat(opts.format):
+		return usageError("unknown format %q (expected text, json or csv)", opts.format)
+	case opts.format != formatText && (opts.verify || opts.benchRuns > 0):
+		return usageError("-format can only be used when running solutions")
+	case opts.timeout < 0:
+		return usageError("-timeout must be positive")
+	case opts.benchRuns < 0:
+		return usageError("-bench must be positive")
+	case opts.benchRuns > 0 && (opts.verify || opts.record):
+		return usageError("-bench cannot be combined with -verify or -record")
+	case opts.render != "" && !render.ValidFormat(opts.render):
+		return usageError("unknown render format %q (expected png, svg or ansi)", opts.render)
+	case opts.render != "" && opts.benchRuns > 0:
+		return usageError("-render cannot be combined with -bench")
+	case opts.blinks < 0 || opts.depth < 1:
+		return usageError("-blinks must not be negative and -depth must be positive")
+	case len(opts.params) > 0 && (opts.verify || opts.record):
+		return usageError("-blinks and -depth change the answers, so they cannot be combined with -verify or -record")
 	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if opts.benchRuns > 0 {
+		return runBench(ctx, opts, days)
+	}
+	return runSolutions(ctx, opts, days)
+}
+
+func main() {
+	os.Exit(run())
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"aoc2024/shared/solver"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+type record struct {
+	Day        int           `json:"day"`
+	Part       int           `json:"part"`
+	Answer     solver.Result `json:"answer"`
+	DurationNs int64         `json:"duration_ns"`
+	Error      *string       `json:"error"`
+}
+
+func validFormat(format string) bool {
+	return format == formatText || format == formatJSON || format == formatCSV
+}
+
+// records flattens the reports into one record per part, leaving out parts that have no solution.
+func records(reports []dayReport) []record {
+	var result []record
+	for _, r := range reports {
+		for i, part := range r.parts {
+			if errors.Is(part.err, solver.ErrNoSolution) {
+				continue
+			}
+
+			rec := record{Day: r.day, Part: i + 1, Answer: part.answer, DurationNs: part.duration.Nanoseconds()}
+			if part.err != nil {
+				msg := part.err.Error()
+				rec.Error = &msg
+			}
+			result = append(result, rec)
+		}
+	}
+	return result
+}
+
+func printJSON(w io.Writer, reports []dayReport) error {
+	recs := records(reports)
+	if recs == nil {
+		recs = []record{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(recs)
+}
+
+func printCSV(w io.Writer, reports []dayReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"day", "part", "answer", "duration_ns", "error"}); err != nil {
+		return err
+	}
+
+	for _, rec := range records(reports) {
+		answer, errMsg := "", ""
+		if rec.Error != nil {
+			errMsg = *rec.Error
+		} else {
+			answer = rec.Answer.String()
+		}
+
+		err := cw.Write([]string{
+			strconv.Itoa(rec.Day),
+			strconv.Itoa(rec.Part),
+			answer,
+			strconv.FormatInt(rec.DurationNs, 10),
+			errMsg,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func printReports(w io.Writer, format string, reports []dayReport) error {
+	switch format {
+	case formatJSON:
+		return printJSON(w, reports)
+	case formatCSV:
+		return printCSV(w, reports)
+	case formatText:
+		if len(reports) == 1 {
+			printPart(w, 1, reports[0].parts[0])
+			printPart(w, 2, reports[0].parts[1])
+			return nil
+		}
+		return printTable(w, reports)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"aoc2024/shared/solver"
+)
+
+var outputReports = []dayReport{
+	{day: 17, parts: [2]partReport{
+		{answer: solver.StringResult("4,6,3"), duration: 1500 * time.Nanosecond},
+		{err: errors.New("boom"), duration: 20 * time.Nanosecond},
+	}},
+	{day: 25, parts: [2]partReport{
+		{answer: solver.IntResult(3), duration: 7 * time.Nanosecond},
+		{err: solver.ErrNoSolution},
+	}},
+}
+
+func TestPrintJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printReports(&buf, formatJSON, outputReports); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d records, want 3 without the part that has no solution: %v", len(got), got)
+	}
+
+	if got[0]["answer"] != "4,6,3" || got[0]["duration_ns"] != 1500.0 || got[0]["error"] != nil {
+		t.Errorf("day 17 part 1 = %v", got[0])
+	}
+	if got[1]["answer"] != nil || got[1]["error"] != "boom" {
+		t.Errorf("day 17 part 2 = %v, want a null answer and the error", got[1])
+	}
+	if got[2]["day"] != 25.0 || got[2]["part"] != 1.0 || got[2]["answer"] != 3.0 {
+		t.Errorf("day 25 part 1 = %v, want the answer as a number", got[2])
+	}
+}
+
+func TestPrintJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printReports(&buf, formatJSON, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("got %q, want an empty array", got)
+	}
+}
+
+func TestPrintCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printReports(&buf, formatCSV, outputReports); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"day", "part", "answer", "duration_ns", "error"},
+		{"17", "1", "4,6,3", "1500", ""},
+		{"17", "2", "", "20", "boom"},
+		{"25", "1", "3", "7", ""},
+	}
+	if !slices.EqualFunc(rows, want, slices.Equal) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestPrintReportsUnknownFormat(t *testing.T) {
+	if err := printReports(&bytes.Buffer{}, "xml", outputReports); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
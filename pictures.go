@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"aoc2024/shared/render"
+)
+
+// pictureWriter saves the pictures days report while they run: images go to files in the current
+// directory, and ANSI pictures to stderr so they do not mix with the answers.
+type pictureWriter struct {
+	format string
+	stderr io.Writer
+	create func(name string) (io.WriteCloser, error)
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func newPictureWriter(format string) *pictureWriter {
+	return &pictureWriter{
+		format: format,
+		stderr: os.Stderr,
+		create: func(name string) (io.WriteCloser, error) { return os.Create(name) },
+	}
+}
+
+func picturePath(day int, name string, format string) string {
+	return fmt.Sprintf("day%02d-%s.%s", day, name, format)
+}
+
+// reporter returns the callback that receives the pictures of a day.
+func (pw *pictureWriter) reporter(day int) func(string, render.Picture) {
+	return func(name string, picture render.Picture) {
+		if err := pw.write(day, name, picture); err != nil {
+			pw.mu.Lock()
+			pw.errs = append(pw.errs, fmt.Errorf("day %d: rendering %s: %w", day, name, err))
+			pw.mu.Unlock()
+		}
+	}
+}
+
+func (pw *pictureWriter) write(day int, name string, picture render.Picture) (err error) {
+	if pw.format == render.FormatANSI {
+		fmt.Fprintf(pw.stderr, "day %d: %s\n", day, name)
+		return picture(pw.stderr, pw.format)
+	}
+
+	w, err := pw.create(picturePath(day, name, pw.format))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := w.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	return picture(w, pw.format)
+}
+
+// errors returns the pictures that could not be written.
+func (pw *pictureWriter) errors() []error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	return pw.errs
+}
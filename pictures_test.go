@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"aoc2024/shared/render"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func picture(content string) render.Picture {
+	return func(w io.Writer, format string) error {
+		_, err := io.WriteString(w, format+":"+content)
+		return err
+	}
+}
+
+func TestPictureWriterFiles(t *testing.T) {
+	files := make(map[string]*bytes.Buffer)
+	pw := newPictureWriter(render.FormatPNG)
+	pw.create = func(name string) (io.WriteCloser, error) {
+		files[name] = &bytes.Buffer{}
+		return nopWriteCloser{files[name]}, nil
+	}
+
+	pw.reporter(16)("best-tiles", picture("maze"))
+
+	buf, ok := files["day16-best-tiles.png"]
+	if !ok {
+		t.Fatalf("wrote %v, want day16-best-tiles.png", files)
+	}
+	if buf.String() != "png:maze" {
+		t.Errorf("file holds %q, want %q", buf.String(), "png:maze")
+	}
+	if errs := pw.errors(); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestPictureWriterANSI(t *testing.T) {
+	var stderr bytes.Buffer
+	pw := newPictureWriter(render.FormatANSI)
+	pw.stderr = &stderr
+
+	pw.reporter(14)("tree", picture("robots"))
+
+	if got, want := stderr.String(), "day 14: tree\nansi:robots"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestPictureWriterCollectsErrors(t *testing.T) {
+	pw := newPictureWriter(render.FormatSVG)
+	pw.create = func(string) (io.WriteCloser, error) {
+		return nil, errors.New("read-only file system")
+	}
+
+	pw.reporter(12)("regions", picture("plots"))
+
+	errs := pw.errors()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "day 12: rendering regions") {
+		t.Errorf("errors = %v, want one error for the regions of day 12", errs)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"aoc2024/shared/solver"
+)
+
+const stdinPath = "-"
+
+type partReport struct {
+	answer   solver.Result
+	duration time.Duration
+	err      error
+}
+
+type dayReport struct {
+	day   int
+	parse time.Duration
+	parts [2]partReport
+	err   error
+}
+
+func (r dayReport) total() time.Duration {
+	return r.parse + r.parts[0].duration + r.parts[1].duration
+}
+
+// errors returns the failures of the day with day and part context. Parts without a solution are not failures.
+func (r dayReport) errors() []error {
+	if r.err != nil {
+		return []error{r.err}
+	}
+
+	var errs []error
+	for i, part := range r.parts {
+		if part.err != nil && !errors.Is(part.err, solver.ErrNoSolution) {
+			errs = append(errs, &dayError{day: r.day, stage: stagePart1 + stage(i), err: part.err})
+		}
+	}
+	return errs
+}
+
+func inputPath(day int) string {
+	return fmt.Sprintf("days/day%02d/input.txt", day)
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == stdinPath {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// protect runs f and turns a panic into an error, so that one broken day cannot take down a whole run.
+func protect(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return f()
+}
+
+// watch runs f and waits for it until ctx is done, so that a parse or part that does not return cannot hold up
+// the run. f is skipped when ctx is already done before it starts.
+func watch(ctx context.Context, f func() error) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%w: %w", errSkipped, err)
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- protect(f)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	duration := time.Since(start)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %s", errTimeout, formatDuration(duration))
+	}
+	return duration, err
+}
+
+// parseInput parses a day's input within the same time budget as its parts.
+func parseInput(ctx context.Context, s solver.Solver, day int, path string) (any, time.Duration, error) {
+	r, err := openInput(path)
+	if err != nil {
+		return nil, 0, &dayError{day: day, stage: stageInput, err: err}
+	}
+	defer r.Close()
+
+	var input any
+	duration, err := watch(ctx, func() error {
+		var err error
+		input, err = s.Parse(r)
+		return err
+	})
+	if err != nil {
+		return nil, duration, &dayError{day: day, stage: stageParse, err: err}
+	}
+	return input, duration, nil
+}
+
+// runPart solves a part, giving up once ctx is done even if the part does not return. A part is skipped
+// when ctx is already done before it starts.
+func runPart(ctx context.Context, part func(context.Context, any) (solver.Result, error), input any) partReport {
+	var answer solver.Result
+	duration, err := watch(ctx, func() error {
+		var err error
+		answer, err = part(ctx, input)
+		return err
+	})
+	if err != nil {
+		// The part may still be running, so its answer must not be read.
+		return partReport{duration: duration, err: err}
+	}
+	return partReport{answer: answer, duration: duration}
+}
+
+// runDay parses a day's input and solves both parts, stopping once ctx is done.
+func runDay(ctx context.Context, day int, path string) dayReport {
+	report := dayReport{day: day}
+
+	s, ok := solver.Get(day)
+	if !ok {
+		report.err = &dayError{day: day, stage: stageInput, err: errors.New("no solver registered")}
+		return report.failed()
+	}
+
+	input, parseDuration, err := parseInput(ctx, s, day, path)
+	report.parse = parseDuration
+	if err != nil {
+		report.err = err
+		return report.failed()
+	}
+
+	report.parts[0] = runPart(ctx, s.Part1, input)
+	report.parts[1] = runPart(ctx, s.Part2, input)
+	return report
+}
+
+// failed marks both parts as failed with the error of the day.
+func (r dayReport) failed() dayReport {
+	for i := range r.parts {
+		r.parts[i].err = r.err
+	}
+	return r
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aoc2024/shared/solver"
+)
+
+func answer(v int) func(context.Context, any) (solver.Result, error) {
+	return func(context.Context, any) (solver.Result, error) {
+		return solver.NewResult(v), nil
+	}
+}
+
+// hang ignores ctx and never returns, like a part stuck in a loop that does not check it.
+func hang(context.Context, any) (solver.Result, error) {
+	select {}
+}
+
+func TestRunPart(t *testing.T) {
+	report := runPart(context.Background(), answer(42), nil)
+	if report.err != nil {
+		t.Fatalf("unexpected error: %v", report.err)
+	}
+	if !report.answer.Equal(solver.NewResult(42)) {
+		t.Errorf("answer = %v, want 42", report.answer)
+	}
+}
+
+func TestRunPartTimesOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	report := runPart(ctx, hang, nil)
+	if !errors.Is(report.err, errTimeout) {
+		t.Errorf("err = %v, want %v", report.err, errTimeout)
+	}
+}
+
+func TestRunPartSkipsAfterDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	started := false
+	report := runPart(ctx, func(ctx context.Context, input any) (solver.Result, error) {
+		started = true
+		return answer(1)(ctx, input)
+	}, nil)
+	if started {
+		t.Errorf("part ran although the deadline had passed")
+	}
+	if !errors.Is(report.err, errSkipped) || errors.Is(report.err, errTimeout) {
+		t.Errorf("err = %v, want %v", report.err, errSkipped)
+	}
+	if got := exitCode(&dayError{day: 1, stage: stagePart2, err: report.err}); got != exitTimeout {
+		t.Errorf("exit code = %d, want %d", got, exitTimeout)
+	}
+}
+
+func TestParseInputTimesOut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	s := solver.New(
+		func(io.Reader) (int, error) { select {} },
+		func(context.Context, int) (int, error) { return 0, nil },
+		func(context.Context, int) (int, error) { return 0, nil },
+	)
+	_, _, err := parseInput(ctx, s, 1, path)
+	if !errors.Is(err, errTimeout) {
+		t.Errorf("err = %v, want %v", err, errTimeout)
+	}
+	if got := exitCode(err); got != exitTimeout {
+		t.Errorf("exit code = %d, want %d", got, exitTimeout)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"aoc2024/shared/solver"
+)
+
+// parseDays turns a -day value such as "all", "7", "10-15" or "1,3,20-25" into a sorted list of days.
+func parseDays(spec string) ([]int, error) {
+	if spec == "all" {
+		return solver.Days(), nil
+	}
+
+	selected := make(map[int]bool)
+	for _, part := range strings.Split(spec, ",") {
+		first, last, err := parseDayRange(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+
+		for day := first; day <= last; day++ {
+			if _, ok := solver.Get(day); !ok {
+				return nil, fmt.Errorf("no solver registered for day %d", day)
+			}
+			selected[day] = true
+		}
+	}
+
+	var days []int
+	for _, day := range solver.Days() {
+		if selected[day] {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func parseDayRange(rangeStr string) (int, int, error) {
+	firstStr, lastStr, isRange := strings.Cut(rangeStr, "-")
+
+	first, err := strconv.Atoi(firstStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid day %q", rangeStr)
+	}
+	if !isRange {
+		return first, first, nil
+	}
+
+	last, err := strconv.Atoi(lastStr)
+	if err != nil || last < first {
+		return 0, 0, fmt.Errorf("invalid day range %q", rangeStr)
+	}
+	return first, last, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"aoc2024/shared/solver"
+)
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []int
+	}{
+		{spec: "7", want: []int{7}},
+		{spec: "10-15", want: []int{10, 11, 12, 13, 14, 15}},
+		{spec: "3-3", want: []int{3}},
+		{spec: "1,3,20-25", want: []int{1, 3, 20, 21, 22, 23, 24, 25}},
+		{spec: "5, 2", want: []int{2, 5}},
+		{spec: "4,2-5,4", want: []int{2, 3, 4, 5}},
+		{spec: "all", want: solver.Days()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseDays(tt.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseDays(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDaysInvalid(t *testing.T) {
+	for _, spec := range []string{"", "x", "0", "26", "5-3", "1-", "-4", "1,,2", "1-2-3", "24-26"} {
+		t.Run(spec, func(t *testing.T) {
+			if days, err := parseDays(spec); err == nil {
+				t.Errorf("parseDays(%q) = %v, want an error", spec, days)
+			}
+		})
+	}
+}
+
+func TestPrintTable(t *testing.T) {
+	reports := []dayReport{
+		{day: 1, parts: [2]partReport{{answer: solver.IntResult(11)}, {answer: solver.StringResult("abc")}}},
+		{day: 25, parts: [2]partReport{{answer: solver.IntResult(3)}, {err: solver.ErrNoSolution}}},
+	}
+
+	var buf bytes.Buffer
+	if err := printTable(&buf, reports); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want a header, two days and a total:\n%s", len(lines), buf.String())
+	}
+	if fields := strings.Fields(lines[1]); fields[0] != "1" || fields[2] != "11" || fields[4] != "abc" {
+		t.Errorf("day 1 row = %q", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); fields[0] != "25" || fields[4] != "-" {
+		t.Errorf("day 25 row = %q, want - for the missing part 2", lines[2])
+	}
+}
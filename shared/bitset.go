@@ -0,0 +1,285 @@
+package shared
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// BitSet is a set of small non-negative integers stored one bit each. It grows as needed, so the size
+// given to NewBitSet is only a hint. The zero value is an empty set ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+func NewBitSet(size int) *BitSet {
+	return &BitSet{words: make([]uint64, (size+63)/64)}
+}
+
+// Add adds i, which must not be negative.
+func (b *BitSet) Add(i int) {
+	if i < 0 {
+		panic(fmt.Sprintf("BitSet: cannot add negative item %d", i))
+	}
+
+	word := i / 64
+	if word >= len(b.words) {
+		b.words = append(b.words, make([]uint64, word-len(b.words)+1)...)
+	}
+	b.words[word] |= 1 << (i % 64)
+}
+
+func (b *BitSet) Remove(i int) {
+	if word := i / 64; i >= 0 && word < len(b.words) {
+		b.words[word] &^= 1 << (i % 64)
+	}
+}
+
+func (b *BitSet) Contains(i int) bool {
+	word := i / 64
+	return i >= 0 && word < len(b.words) && b.words[word]&(1<<(i%64)) != 0
+}
+
+func (b *BitSet) Size() int {
+	size := 0
+	for _, w := range b.words {
+		size += bits.OnesCount64(w)
+	}
+	return size
+}
+
+// Clear removes every item but keeps the storage for reuse.
+func (b *BitSet) Clear() {
+	clear(b.words)
+}
+
+// All iterates over the items in ascending order.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*64 + bit) {
+					return
+				}
+				w &^= 1 << bit
+			}
+		}
+	}
+}
+
+func (b *BitSet) Items() []int {
+	return slices.Collect(b.All())
+}
+
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: slices.Clone(b.words)}
+}
+
+func (b *BitSet) Absorb(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// Union returns the items that are in either set.
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	union := b.Clone()
+	union.Absorb(other)
+	return union
+}
+
+// Intersection returns the items that are in both sets.
+func (b *BitSet) Intersection(other *BitSet) *BitSet {
+	intersection := &BitSet{words: make([]uint64, min(len(b.words), len(other.words)))}
+	for i := range intersection.words {
+		intersection.words[i] = b.words[i] & other.words[i]
+	}
+	return intersection
+}
+
+// Difference returns the items that are in b but not in other.
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	diff := b.Clone()
+	for i := range min(len(diff.words), len(other.words)) {
+		diff.words[i] &^= other.words[i]
+	}
+	return diff
+}
+
+// SymmetricDifference returns the items that are in exactly one of the sets.
+func (b *BitSet) SymmetricDifference(other *BitSet) *BitSet {
+	diff := b.Clone()
+	if len(other.words) > len(diff.words) {
+		diff.words = append(diff.words, make([]uint64, len(other.words)-len(diff.words))...)
+	}
+	for i, w := range other.words {
+		diff.words[i] ^= w
+	}
+	return diff
+}
+
+// IsSubset reports whether every item of b is also in other.
+func (b *BitSet) IsSubset(other *BitSet) bool {
+	for i, w := range b.words {
+		var o uint64
+		if i < len(other.words) {
+			o = other.words[i]
+		}
+		if w&^o != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) Equal(other *BitSet) bool {
+	return b.IsSubset(other) && other.IsSubset(b)
+}
+
+func (b *BitSet) String() string {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i := range b.All() {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, i)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// IndexSet is a set of values that map one-to-one onto the integers 0 to size-1, such as the points of a
+// grid. It stores them in a BitSet, which is much faster than a map for dense sets.
+type IndexSet[T any] struct {
+	bits      *BitSet
+	size      int
+	toIndex   func(T) int
+	fromIndex func(int) T
+}
+
+// NewIndexSet creates an IndexSet for size values. toIndex and fromIndex convert between a value and its index.
+// Values that toIndex maps outside 0 to size-1, for example to -1, are never in the set and cannot be added.
+func NewIndexSet[T any](size int, toIndex func(T) int, fromIndex func(int) T) *IndexSet[T] {
+	return &IndexSet[T]{bits: NewBitSet(size), size: size, toIndex: toIndex, fromIndex: fromIndex}
+}
+
+// NewPointSet creates an IndexSet for the points of a width by height grid.
+func NewPointSet(width int, height int) *IndexSet[Point] {
+	toIndex := func(p Point) int {
+		if p.X < 0 || p.X >= width || p.Y < 0 || p.Y >= height {
+			return -1
+		}
+		return p.Y*width + p.X
+	}
+	fromIndex := func(i int) Point { return NewPoint(i%width, i/width) }
+	return NewIndexSet(width*height, toIndex, fromIndex)
+}
+
+// Add adds item. It panics if item is outside the values the set was created for, as storing it would mix it
+// up with another value.
+func (s *IndexSet[T]) Add(item T) {
+	i, ok := s.index(item)
+	if !ok {
+		panic(fmt.Sprintf("IndexSet: %v is out of range", item))
+	}
+	s.bits.Add(i)
+}
+
+func (s *IndexSet[T]) Remove(item T) {
+	if i, ok := s.index(item); ok {
+		s.bits.Remove(i)
+	}
+}
+
+func (s *IndexSet[T]) Contains(item T) bool {
+	i, ok := s.index(item)
+	return ok && s.bits.Contains(i)
+}
+
+func (s *IndexSet[T]) index(item T) (int, bool) {
+	i := s.toIndex(item)
+	return i, i >= 0 && i < s.size
+}
+
+func (s *IndexSet[T]) Size() int {
+	return s.bits.Size()
+}
+
+// Clear removes every item but keeps the storage for reuse.
+func (s *IndexSet[T]) Clear() {
+	s.bits.Clear()
+}
+
+// All iterates over the items in index order.
+func (s *IndexSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range s.bits.All() {
+			if !yield(s.fromIndex(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (s *IndexSet[T]) Items() []T {
+	return slices.Collect(s.All())
+}
+
+func (s *IndexSet[T]) Clone() *IndexSet[T] {
+	return s.with(s.bits.Clone())
+}
+
+// Absorb adds the items of other, which must use the same index mapping.
+func (s *IndexSet[T]) Absorb(other *IndexSet[T]) {
+	s.bits.Absorb(other.bits)
+}
+
+// Union returns the items that are in either set. Both sets must use the same index mapping, as for every
+// operation that combines two sets.
+func (s *IndexSet[T]) Union(other *IndexSet[T]) *IndexSet[T] {
+	return s.with(s.bits.Union(other.bits))
+}
+
+func (s *IndexSet[T]) Intersection(other *IndexSet[T]) *IndexSet[T] {
+	return s.with(s.bits.Intersection(other.bits))
+}
+
+func (s *IndexSet[T]) Difference(other *IndexSet[T]) *IndexSet[T] {
+	return s.with(s.bits.Difference(other.bits))
+}
+
+func (s *IndexSet[T]) SymmetricDifference(other *IndexSet[T]) *IndexSet[T] {
+	return s.with(s.bits.SymmetricDifference(other.bits))
+}
+
+func (s *IndexSet[T]) IsSubset(other *IndexSet[T]) bool {
+	return s.bits.IsSubset(other.bits)
+}
+
+func (s *IndexSet[T]) Equal(other *IndexSet[T]) bool {
+	return s.bits.Equal(other.bits)
+}
+
+func (s *IndexSet[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for item := range s.All() {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%v", item)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func (s *IndexSet[T]) with(b *BitSet) *IndexSet[T] {
+	return &IndexSet[T]{bits: b, size: s.size, toIndex: s.toIndex, fromIndex: s.fromIndex}
+}
@@ -0,0 +1,165 @@
+package shared
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	b := NewBitSet(10)
+	for _, i := range []int{3, 64, 200, 3} {
+		b.Add(i)
+	}
+	b.Remove(64)
+	b.Remove(-1)
+
+	if got, want := b.Items(), []int{3, 200}; !slices.Equal(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if b.Size() != 2 || !b.Contains(200) || b.Contains(64) || b.Contains(-1) || b.Contains(1000) {
+		t.Errorf("membership of %v is wrong", b)
+	}
+
+	b.Clear()
+	if b.Size() != 0 {
+		t.Errorf("Clear() left %v", b)
+	}
+}
+
+func TestBitSetAlgebra(t *testing.T) {
+	a := NewBitSet(0)
+	for _, i := range []int{1, 2, 100} {
+		a.Add(i)
+	}
+	var b BitSet
+	for _, i := range []int{2, 3} {
+		b.Add(i)
+	}
+
+	tests := []struct {
+		name string
+		got  *BitSet
+		want []int
+	}{
+		{name: "union", got: a.Union(&b), want: []int{1, 2, 3, 100}},
+		{name: "intersection", got: a.Intersection(&b), want: []int{2}},
+		{name: "difference", got: a.Difference(&b), want: []int{1, 100}},
+		{name: "symmetric difference", got: b.SymmetricDifference(a), want: []int{1, 3, 100}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.got.Items(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if !a.Intersection(&b).IsSubset(&b) || a.IsSubset(&b) {
+		t.Errorf("IsSubset got the containment of %v and %v wrong", a, &b)
+	}
+	if !a.Equal(a.Union(NewBitSet(500))) || a.Equal(&b) {
+		t.Errorf("Equal got the equality of %v wrong", a)
+	}
+}
+
+func TestPointSet(t *testing.T) {
+	s := NewPointSet(4, 3)
+	s.Add(NewPoint(3, 2))
+	s.Add(NewPoint(1, 0))
+
+	want := []Point{NewPoint(1, 0), NewPoint(3, 2)}
+	if got := s.Items(); !slices.Equal(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if s.Contains(NewPoint(2, 1)) || !s.Contains(NewPoint(3, 2)) {
+		t.Errorf("membership of %v is wrong", s)
+	}
+
+	other := NewPointSet(4, 3)
+	other.Add(NewPoint(1, 0))
+	if !other.IsSubset(s) || !s.Difference(other).Equal(s.SymmetricDifference(other)) {
+		t.Errorf("set algebra on %v and %v is wrong", s, other)
+	}
+}
+
+func TestPointSetOutOfRange(t *testing.T) {
+	s := NewPointSet(3, 3)
+	s.Add(NewPoint(0, 1))
+	s.Add(NewPoint(2, 2))
+
+	for _, p := range []Point{NewPoint(-1, 0), NewPoint(3, 0), NewPoint(-1, 2), NewPoint(0, 3), NewPoint(0, -1)} {
+		if s.Contains(p) {
+			t.Errorf("Contains(%v) = true for a point outside the grid", p)
+		}
+		s.Remove(p)
+		assertPanics(t, fmt.Sprintf("Add(%v)", p), func() { s.Add(p) })
+	}
+
+	want := []Point{NewPoint(0, 1), NewPoint(2, 2)}
+	if got := s.Items(); !slices.Equal(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+}
+
+func TestBitSetNegative(t *testing.T) {
+	var b BitSet
+	assertPanics(t, "Add(-1)", func() { b.Add(-1) })
+	if b.Contains(-1) {
+		t.Errorf("Contains(-1) = true")
+	}
+	b.Remove(-1)
+}
+
+func assertPanics(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+	f()
+}
+
+// The benchmarks mark every point of a grid as visited twice, the pattern of the grid searches.
+const benchSide = 128
+
+func BenchmarkSetVisit(b *testing.B) {
+	for range b.N {
+		seen := NewSet[Point]()
+		for range 2 {
+			for i := range benchSide * benchSide {
+				p := NewPoint(i%benchSide, i/benchSide)
+				if !seen.Contains(p) {
+					seen.Add(p)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkPointSetVisit(b *testing.B) {
+	for range b.N {
+		seen := NewPointSet(benchSide, benchSide)
+		for range 2 {
+			for i := range benchSide * benchSide {
+				p := NewPoint(i%benchSide, i/benchSide)
+				if !seen.Contains(p) {
+					seen.Add(p)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkBitSetVisit(b *testing.B) {
+	for range b.N {
+		seen := NewBitSet(benchSide * benchSide)
+		for range 2 {
+			for i := range benchSide * benchSide {
+				if !seen.Contains(i) {
+					seen.Add(i)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package shared
+
+import "iter"
+
+// The generators below are lazy: they produce one arrangement at a time and stop as soon as the loop
+// breaks. To avoid allocating, each yields the same slice, overwritten between steps, so a caller that
+// keeps an arrangement has to clone it and must not modify it during the loop.
+
+// CombinationIndices yields every way to choose r of the indices 0 to n-1, each in ascending order, with
+// the combinations in lexicographic order.
+func CombinationIndices(n int, r int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if r < 0 || r > n {
+			return
+		}
+
+		indices := make([]int, r)
+		for i := range indices {
+			indices[i] = i
+		}
+
+		for {
+			if !yield(indices) {
+				return
+			}
+
+			i := r - 1
+			for i >= 0 && indices[i] == i+n-r {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+
+			indices[i]++
+			for j := i + 1; j < r; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+// Combinations yields every way to choose r of the items, keeping their order.
+func Combinations[T any](items []T, r int) iter.Seq[[]T] {
+	return pick(items, CombinationIndices(len(items), r))
+}
+
+// PermutationIndices yields every ordered arrangement of r of the indices 0 to n-1, in lexicographic order.
+func PermutationIndices(n int, r int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if r < 0 || r > n {
+			return
+		}
+
+		pool := make([]int, n)
+		for i := range pool {
+			pool[i] = i
+		}
+		cycles := make([]int, r)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+
+		if !yield(pool[:r]) {
+			return
+		}
+
+		for {
+			i := r - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] > 0 {
+					j := n - cycles[i]
+					pool[i], pool[j] = pool[j], pool[i]
+					if !yield(pool[:r]) {
+						return
+					}
+					break
+				}
+
+				// Every choice for position i has been tried, rotate it back to the end of the pool.
+				first := pool[i]
+				copy(pool[i:], pool[i+1:])
+				pool[n-1] = first
+				cycles[i] = n - i
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// Permutations yields every ordered arrangement of r of the items.
+func Permutations[T any](items []T, r int) iter.Seq[[]T] {
+	return pick(items, PermutationIndices(len(items), r))
+}
+
+// ProductIndices yields every combination of one index per position, where position i has sizes[i]
+// choices. The last position changes fastest.
+func ProductIndices(sizes ...int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, size := range sizes {
+			if size <= 0 {
+				return
+			}
+		}
+
+		indices := make([]int, len(sizes))
+		for {
+			if !yield(indices) {
+				return
+			}
+
+			i := len(indices) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < sizes[i] {
+					break
+				}
+				indices[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// Product yields the cartesian product of the sets: every way to take one item from each.
+func Product[T any](sets ...[]T) iter.Seq[[]T] {
+	sizes := make([]int, len(sets))
+	for i, set := range sets {
+		sizes[i] = len(set)
+	}
+
+	return func(yield func([]T) bool) {
+		values := make([]T, len(sets))
+		for indices := range ProductIndices(sizes...) {
+			for i, index := range indices {
+				values[i] = sets[i][index]
+			}
+			if !yield(values) {
+				return
+			}
+		}
+	}
+}
+
+// ProductRepeat yields every sequence of n items, such as every assignment of operators to the n gaps
+// in an equation.
+func ProductRepeat[T any](items []T, n int) iter.Seq[[]T] {
+	sets := make([][]T, n)
+	for i := range sets {
+		sets[i] = items
+	}
+	return Product(sets...)
+}
+
+// SubsetIndices yields every subset of the indices 0 to n-1, from the smallest to the largest.
+func SubsetIndices(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for r := range n + 1 {
+			for indices := range CombinationIndices(n, r) {
+				if !yield(indices) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// PowerSet yields every subset of the items, from the smallest to the largest.
+func PowerSet[T any](items []T) iter.Seq[[]T] {
+	return pick(items, SubsetIndices(len(items)))
+}
+
+// pick turns a sequence of index slices into the items at those indices.
+func pick[T any](items []T, seq iter.Seq[[]int]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		values := make([]T, 0, len(items))
+		for indices := range seq {
+			values = values[:0]
+			for _, index := range indices {
+				values = append(values, items[index])
+			}
+			if !yield(values) {
+				return
+			}
+		}
+	}
+}
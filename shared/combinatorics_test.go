@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// collect clones every yielded slice, since the generators reuse theirs.
+func collect[T any](seq iter.Seq[[]T]) [][]T {
+	var all [][]T
+	for s := range seq {
+		all = append(all, slices.Clone(s))
+	}
+	return all
+}
+
+func TestCombinatorics(t *testing.T) {
+	items := []string{"a", "b", "c"}
+
+	tests := []struct {
+		name string
+		got  [][]string
+		want string
+	}{
+		{name: "combinations", got: collect(Combinations(items, 2)), want: "[[a b] [a c] [b c]]"},
+		{name: "all combinations", got: collect(Combinations(items, 3)), want: "[[a b c]]"},
+		{name: "empty combination", got: collect(Combinations(items, 0)), want: "[[]]"},
+		{name: "too many", got: collect(Combinations(items, 4)), want: "[]"},
+		{name: "permutations", got: collect(Permutations(items, 2)), want: "[[a b] [a c] [b a] [b c] [c a] [c b]]"},
+		{name: "full permutations", got: collect(Permutations(items, 3)), want: "[[a b c] [a c b] [b a c] [b c a] [c a b] [c b a]]"},
+		{name: "product", got: collect(Product([]string{"a", "b"}, []string{"x"}, []string{"1", "2"})), want: "[[a x 1] [a x 2] [b x 1] [b x 2]]"},
+		{name: "empty product", got: collect(Product([]string{"a"}, nil)), want: "[]"},
+		{name: "repeated product", got: collect(ProductRepeat([]string{"+", "*"}, 2)), want: "[[+ +] [+ *] [* +] [* *]]"},
+		{name: "power set", got: collect(PowerSet(items)), want: "[[] [a] [b] [c] [a b] [a c] [b c] [a b c]]"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprint(tt.got); got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCombinatoricsCounts(t *testing.T) {
+	count := func(seq iter.Seq[[]int]) int {
+		n := 0
+		for range seq {
+			n++
+		}
+		return n
+	}
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "C(10, 4)", got: count(CombinationIndices(10, 4)), want: 210},
+		{name: "P(6, 3)", got: count(PermutationIndices(6, 3)), want: 120},
+		{name: "6!", got: count(PermutationIndices(6, 6)), want: 720},
+		{name: "3^5", got: count(ProductIndices(3, 3, 3, 3, 3)), want: 243},
+		{name: "2^10", got: count(SubsetIndices(10)), want: 1024},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCombinationsStopEarly(t *testing.T) {
+	// Choosing half of 60 items has over 10^17 combinations, so this only finishes if the loop stops.
+	items := make([]int, 60)
+	for i := range items {
+		items[i] = i
+	}
+
+	seen := 0
+	for combo := range Combinations(items, 30) {
+		seen++
+		if combo[29] == 31 {
+			break
+		}
+	}
+	if seen != 3 {
+		t.Errorf("saw %d combinations before breaking, want 3", seen)
+	}
+}
+
+func BenchmarkCombinationIndices(b *testing.B) {
+	for range b.N {
+		for range CombinationIndices(20, 5) {
+		}
+	}
+}
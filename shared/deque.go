@@ -0,0 +1,186 @@
+package shared
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Deque is a double-ended queue in a ring buffer that grows when it is full, so nothing is ever dropped.
+// The zero value is an empty deque ready to use.
+type Deque[T any] struct {
+	items []T
+	front int
+	size  int
+}
+
+func NewDeque[T any](items ...T) *Deque[T] {
+	d := &Deque[T]{}
+	for _, item := range items {
+		d.PushBack(item)
+	}
+	return d
+}
+
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.items[d.index(d.size)] = item
+	d.size++
+}
+
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.front = d.index(len(d.items) - 1)
+	d.items[d.front] = item
+	d.size++
+}
+
+func (d *Deque[T]) PopFront() (T, bool) {
+	item, ok := d.PeekFront()
+	if ok {
+		var zeroValue T
+		d.items[d.front] = zeroValue
+		d.front = d.index(1)
+		d.size--
+	}
+	return item, ok
+}
+
+func (d *Deque[T]) PopBack() (T, bool) {
+	item, ok := d.PeekBack()
+	if ok {
+		var zeroValue T
+		d.items[d.index(d.size-1)] = zeroValue
+		d.size--
+	}
+	return item, ok
+}
+
+func (d *Deque[T]) PeekFront() (T, bool) {
+	if d.size == 0 {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return d.items[d.front], true
+}
+
+func (d *Deque[T]) PeekBack() (T, bool) {
+	if d.size == 0 {
+		var zeroValue T
+		return zeroValue, false
+	}
+	return d.items[d.index(d.size-1)], true
+}
+
+// All iterates from the front to the back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.size {
+			if !yield(d.items[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Deque[T]) String() string {
+	return formatSeq(d.All())
+}
+
+// index returns the position in the ring of the item i places behind the front.
+func (d *Deque[T]) index(i int) int {
+	return (d.front + i) % len(d.items)
+}
+
+// grow makes room for one more item, doubling the ring and unwrapping it when it is full.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.items) {
+		return
+	}
+
+	items := make([]T, max(8, 2*len(d.items)))
+	for i := range d.size {
+		items[i] = d.items[d.index(i)]
+	}
+	d.items, d.front = items, 0
+}
+
+// SlidingWindow keeps the most recent items up to a fixed capacity. Pushing to a full window drops the
+// oldest item, such as when following the last few changes of a sequence.
+type SlidingWindow[T any] struct {
+	items []T
+	start int
+	size  int
+}
+
+func NewSlidingWindow[T any](capacity int) *SlidingWindow[T] {
+	if capacity <= 0 {
+		panic("SlidingWindow: capacity must be positive")
+	}
+	return &SlidingWindow[T]{items: make([]T, capacity)}
+}
+
+// Push adds item as the newest item. When the window was full it returns the oldest item it dropped.
+func (w *SlidingWindow[T]) Push(item T) (dropped T, ok bool) {
+	if w.Full() {
+		dropped, ok = w.items[w.start], true
+		w.items[w.start] = item
+		w.start = (w.start + 1) % len(w.items)
+		return dropped, ok
+	}
+
+	w.items[(w.start+w.size)%len(w.items)] = item
+	w.size++
+	return dropped, false
+}
+
+func (w *SlidingWindow[T]) Len() int {
+	return w.size
+}
+
+func (w *SlidingWindow[T]) Capacity() int {
+	return len(w.items)
+}
+
+func (w *SlidingWindow[T]) Full() bool {
+	return w.size == len(w.items)
+}
+
+// At returns the item i places after the oldest one.
+func (w *SlidingWindow[T]) At(i int) T {
+	if i < 0 || i >= w.size {
+		panic(fmt.Sprintf("SlidingWindow: index %d out of range [0:%d]", i, w.size))
+	}
+	return w.items[(w.start+i)%len(w.items)]
+}
+
+// All iterates from the oldest item to the newest.
+func (w *SlidingWindow[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range w.size {
+			if !yield(w.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (w *SlidingWindow[T]) String() string {
+	return formatSeq(w.All())
+}
+
+func formatSeq[T any](seq iter.Seq[T]) string {
+	var items []string
+	for item := range seq {
+		items = append(items, fmt.Sprintf("%v", item))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
@@ -0,0 +1,84 @@
+package shared
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("PopFront() on an empty deque reported an item")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("PopBack() on an empty deque reported an item")
+	}
+
+	// Push enough from both ends to wrap around and grow the ring a few times.
+	for i := range 20 {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+
+	want := make([]int, 0, 40)
+	for i := -20; i < 20; i++ {
+		want = append(want, i)
+	}
+	if got := slices.Collect(d.All()); !slices.Equal(got, want) {
+		t.Fatalf("All() = %v, want %v", got, want)
+	}
+
+	if front, _ := d.PopFront(); front != -20 {
+		t.Errorf("PopFront() = %d, want -20", front)
+	}
+	if back, _ := d.PopBack(); back != 19 {
+		t.Errorf("PopBack() = %d, want 19", back)
+	}
+	if d.Len() != 38 {
+		t.Errorf("Len() = %d, want 38", d.Len())
+	}
+}
+
+func TestDequeAsQueue(t *testing.T) {
+	d := NewDeque(1, 2)
+	d.PushBack(3)
+
+	var got []int
+	for !d.IsEmpty() {
+		item, _ := d.PopFront()
+		got = append(got, item)
+		if item == 2 {
+			d.PushBack(4)
+		}
+	}
+	if want := []int{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	w := NewSlidingWindow[int](3)
+	for i := 1; i <= 3; i++ {
+		if _, dropped := w.Push(i); dropped {
+			t.Errorf("Push(%d) dropped an item before the window was full", i)
+		}
+	}
+	if !w.Full() {
+		t.Errorf("window with %d of %d items is not full", w.Len(), w.Capacity())
+	}
+
+	if oldest, dropped := w.Push(4); !dropped || oldest != 1 {
+		t.Errorf("Push(4) = %d, %v, want 1, true", oldest, dropped)
+	}
+	w.Push(5)
+
+	if got, want := slices.Collect(w.All()), []int{3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if w.At(0) != 3 || w.At(2) != 5 {
+		t.Errorf("At() does not count from the oldest item in %v", w)
+	}
+	if got, want := w.String(), "[3, 4, 5]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package shared
+
+import "fmt"
+
+// Direction is one of the eight compass directions, in clockwise order starting at North.
+// North points up the grid, towards smaller Y.
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+var CardinalDirections = []Direction{North, East, South, West}
+
+var AllDirections = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+var directionDeltas = [8]Point{
+	North:     {X: 0, Y: -1},
+	NorthEast: {X: 1, Y: -1},
+	East:      {X: 1, Y: 0},
+	SouthEast: {X: 1, Y: 1},
+	South:     {X: 0, Y: 1},
+	SouthWest: {X: -1, Y: 1},
+	West:      {X: -1, Y: 0},
+	NorthWest: {X: -1, Y: -1},
+}
+
+var directionNames = [8]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// ParseDirection reads a cardinal direction written as an arrow (^ > v <) or a compass letter (N E S W).
+func ParseDirection(r rune) (Direction, error) {
+	switch r {
+	case '^', 'N':
+		return North, nil
+	case '>', 'E':
+		return East, nil
+	case 'v', 'S':
+		return South, nil
+	case '<', 'W':
+		return West, nil
+	}
+	return 0, fmt.Errorf("invalid direction %q", r)
+}
+
+// TurnRight turns 90 degrees clockwise.
+func (d Direction) TurnRight() Direction {
+	return (d + 2) % 8
+}
+
+// TurnLeft turns 90 degrees counterclockwise.
+func (d Direction) TurnLeft() Direction {
+	return (d + 6) % 8
+}
+
+func (d Direction) Reverse() Direction {
+	return (d + 4) % 8
+}
+
+func (d Direction) IsDiagonal() bool {
+	return d%2 == 1
+}
+
+// Delta is the step a single move in this direction makes.
+func (d Direction) Delta() Point {
+	return directionDeltas[d]
+}
+
+func (d Direction) String() string {
+	return directionNames[d]
+}
+
+func (p Point) Move(d Direction, n int) Point {
+	delta := d.Delta()
+	return NewPoint(p.X+n*delta.X, p.Y+n*delta.Y)
+}
@@ -0,0 +1,61 @@
+package shared
+
+import "testing"
+
+func TestDirectionTurns(t *testing.T) {
+	for _, d := range AllDirections {
+		if got := d.TurnRight().TurnLeft(); got != d {
+			t.Errorf("%v: right then left = %v", d, got)
+		}
+		if got := d.TurnRight().TurnRight(); got != d.Reverse() {
+			t.Errorf("%v: two right turns = %v, want %v", d, got, d.Reverse())
+		}
+		if got := d.Reverse().Delta(); got.X != -d.Delta().X || got.Y != -d.Delta().Y {
+			t.Errorf("%v: reversed delta = %v", d, got)
+		}
+	}
+
+	if got := North.TurnRight(); got != East {
+		t.Errorf("North.TurnRight() = %v, want E", got)
+	}
+	if got := NorthEast.TurnLeft(); got != NorthWest {
+		t.Errorf("NorthEast.TurnLeft() = %v, want NW", got)
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	tests := []struct {
+		r    rune
+		want Direction
+	}{
+		{r: '^', want: North}, {r: 'N', want: North},
+		{r: '>', want: East}, {r: 'E', want: East},
+		{r: 'v', want: South}, {r: 'S', want: South},
+		{r: '<', want: West}, {r: 'W', want: West},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDirection(tt.r)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDirection(%q) = %v, %v, want %v", tt.r, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParseDirection('x'); err == nil {
+		t.Errorf("ParseDirection('x') did not fail")
+	}
+}
+
+func TestPointMove(t *testing.T) {
+	p := NewPoint(2, 3)
+
+	if got := p.Move(North, 1); got != p.Up() {
+		t.Errorf("Move(North, 1) = %v, want %v", got, p.Up())
+	}
+	if got := p.Move(SouthWest, 2); got != NewPoint(0, 5) {
+		t.Errorf("Move(SouthWest, 2) = %v, want (0, 5)", got)
+	}
+	if got := p.Move(East, -3); got != NewPoint(-1, 3) {
+		t.Errorf("Move(East, -3) = %v, want (-1, 3)", got)
+	}
+}
@@ -0,0 +1,193 @@
+package shared
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// maxPatches is how many cells a clone may change before it takes its own copy of the cells.
+const maxPatches = 8
+
+type patch[T any] struct {
+	index int
+	value T
+}
+
+// flatCells holds the cells of a FlatGrid. Once a clone refers to them they are shared, and from then on
+// nothing writes to them: a grid that changes shared cells records patches or copies them instead. Keeping
+// the flag next to the cells means every copy of a grid value sees it.
+type flatCells[T any] struct {
+	values []T
+	shared bool
+}
+
+// FlatGrid is a dense grid stored row after row in a single slice, which keeps neighboring
+// cells close in memory. Clones share their cells until they change a few of them, so
+// trying out a single change on a copy of a large grid is cheap. The zero value is an empty grid.
+type FlatGrid[T any] struct {
+	cells   *flatCells[T]
+	width   int
+	height  int
+	patches []patch[T]
+}
+
+func NewFlatGrid[T any](width int, height int, fill T) FlatGrid[T] {
+	cells := make([]T, width*height)
+	for i := range cells {
+		cells[i] = fill
+	}
+	return newFlatGrid(cells, width, height)
+}
+
+// NewFlatGridFrom copies a Grid. It returns an error unless every row is as long as the first one.
+func NewFlatGridFrom[T any](grid Grid[T]) (FlatGrid[T], error) {
+	height := len(grid.Points)
+	if height == 0 {
+		return newFlatGrid[T](nil, 0, 0), nil
+	}
+
+	width := len(grid.Points[0])
+	cells := make([]T, 0, width*height)
+	for y, row := range grid.Points {
+		if len(row) != width {
+			return FlatGrid[T]{}, fmt.Errorf("row %d has %d cells, want %d", y, len(row), width)
+		}
+		cells = append(cells, row...)
+	}
+	return newFlatGrid(cells, width, height), nil
+}
+
+func newFlatGrid[T any](cells []T, width int, height int) FlatGrid[T] {
+	return FlatGrid[T]{cells: &flatCells[T]{values: cells}, width: width, height: height}
+}
+
+func (g *FlatGrid[T]) Width() int {
+	return g.width
+}
+
+func (g *FlatGrid[T]) Height() int {
+	return g.height
+}
+
+// Index returns where p is stored. p must be inside the grid.
+func (g *FlatGrid[T]) Index(p Point) int {
+	return p.Y*g.width + p.X
+}
+
+func (g *FlatGrid[T]) Point(index int) Point {
+	return NewPoint(index%g.width, index/g.width)
+}
+
+func (g *FlatGrid[T]) Contains(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+func (g *FlatGrid[T]) Get(p Point) T {
+	return g.GetIndex(g.Index(p))
+}
+
+func (g *FlatGrid[T]) GetIndex(index int) T {
+	for i := len(g.patches) - 1; i >= 0; i-- {
+		if g.patches[i].index == index {
+			return g.patches[i].value
+		}
+	}
+	return g.values()[index]
+}
+
+// values returns the cells without the patches. It is nil for the zero value, so indexing it panics
+// with an out of range index like any empty slice.
+func (g *FlatGrid[T]) values() []T {
+	if g.cells == nil {
+		return nil
+	}
+	return g.cells.values
+}
+
+func (g *FlatGrid[T]) Set(p Point, value T) {
+	g.SetIndex(g.Index(p), value)
+}
+
+func (g *FlatGrid[T]) SetIndex(index int, value T) {
+	if g.cells == nil || !g.cells.shared {
+		g.values()[index] = value
+		return
+	}
+
+	// The patches are cloned rather than changed in place, as a copy of g may hold the same ones.
+	patches := slices.Clone(g.patches)
+	for i := range patches {
+		if patches[i].index == index {
+			patches[i].value = value
+			g.patches = patches
+			return
+		}
+	}
+
+	if len(patches) < maxPatches {
+		g.patches = append(patches, patch[T]{index: index, value: value})
+		return
+	}
+
+	cells := slices.Clone(g.cells.values)
+	for _, p := range patches {
+		cells[p.index] = p.value
+	}
+	cells[index] = value
+	g.cells, g.patches = &flatCells[T]{values: cells}, nil
+}
+
+// Clone returns a copy that shares the cells with g until either of them changes more than a few.
+// Copying a FlatGrid value without Clone shares the cells outright, so only a clone is safe to change
+// independently.
+func (g *FlatGrid[T]) Clone() FlatGrid[T] {
+	if g.cells == nil {
+		return FlatGrid[T]{}
+	}
+
+	g.cells.shared = true
+	return FlatGrid[T]{
+		cells:   g.cells,
+		width:   g.width,
+		height:  g.height,
+		patches: slices.Clone(g.patches),
+	}
+}
+
+// Cells iterates over every cell, row by row.
+func (g *FlatGrid[T]) Cells() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i := range g.width * g.height {
+			if !yield(g.Point(i), g.GetIndex(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors iterates over the cardinal neighbors of p that are inside the grid.
+func (g *FlatGrid[T]) Neighbors(p Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, dir := range CardinalDirections {
+			neighbor := p.Move(dir, 1)
+			if g.Contains(neighbor) && !yield(neighbor) {
+				return
+			}
+		}
+	}
+}
+
+func (g *FlatGrid[T]) Print() {
+	for y := range g.height {
+		for x := range g.width {
+			switch v := any(g.Get(NewPoint(x, y))).(type) {
+			case rune:
+				fmt.Print(string(v))
+			default:
+				fmt.Print(v)
+			}
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,164 @@
+package shared
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFlatGridIndex(t *testing.T) {
+	g, err := NewFlatGridFrom(NewGrid([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if g.Width() != 3 || g.Height() != 2 {
+		t.Fatalf("size = %dx%d, want 3x2", g.Width(), g.Height())
+	}
+
+	for i := range 6 {
+		p := g.Point(i)
+		if g.Index(p) != i || g.Get(p) != i+1 {
+			t.Errorf("index %d: point %v, index back %d, value %d", i, p, g.Index(p), g.Get(p))
+		}
+	}
+
+	if g.Contains(NewPoint(3, 0)) || g.Contains(NewPoint(0, -1)) || !g.Contains(NewPoint(2, 1)) {
+		t.Errorf("Contains does not match the bounds")
+	}
+}
+
+func TestFlatGridFromRaggedRows(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]int
+	}{
+		{name: "short last row", rows: [][]int{{1, 2, 3}, {4, 5}}},
+		{name: "long row", rows: [][]int{{1, 2}, {3, 4, 5}}},
+		// Six cells fit a 2x3 grid, but the rows are ragged.
+		{name: "same total", rows: [][]int{{1, 2}, {3, 4, 5}, {6}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFlatGridFrom(NewGrid(tt.rows)); err == nil {
+				t.Errorf("expected an error for rows %v", tt.rows)
+			}
+		})
+	}
+}
+
+func TestFlatGridIterators(t *testing.T) {
+	g := NewFlatGrid(3, 3, 0)
+	g.Set(NewPoint(1, 1), 7)
+
+	total := 0
+	for p, v := range g.Cells() {
+		if v == 7 && p != NewPoint(1, 1) {
+			t.Errorf("found the marked cell at %v", p)
+		}
+		total += v
+	}
+	if total != 7 {
+		t.Errorf("cells add up to %d, want 7", total)
+	}
+
+	var corner []Point
+	for n := range g.Neighbors(NewPoint(0, 0)) {
+		corner = append(corner, n)
+	}
+	if !slices.Equal(corner, []Point{NewPoint(1, 0), NewPoint(0, 1)}) {
+		t.Errorf("neighbors of the corner = %v", corner)
+	}
+
+	count := 0
+	for range g.Neighbors(NewPoint(1, 1)) {
+		count++
+	}
+	if count != 4 {
+		t.Errorf("the center has %d neighbors, want 4", count)
+	}
+}
+
+func TestFlatGridCloneIsIndependent(t *testing.T) {
+	g := NewFlatGrid(4, 4, '.')
+	clone := g.Clone()
+
+	clone.Set(NewPoint(1, 1), '#')
+	g.Set(NewPoint(2, 2), '@')
+
+	if g.Get(NewPoint(1, 1)) != '.' {
+		t.Errorf("a write to the clone changed the original")
+	}
+	if clone.Get(NewPoint(2, 2)) != '.' {
+		t.Errorf("a write to the original changed the clone")
+	}
+
+	// enough writes to make the clone take its own copy of the cells
+	for x := range 4 {
+		for y := range 4 {
+			clone.Set(NewPoint(x, y), 'x')
+		}
+	}
+	if g.Get(NewPoint(0, 0)) != '.' || g.Get(NewPoint(2, 2)) != '@' {
+		t.Errorf("filling the clone changed the original")
+	}
+	if clone.Get(NewPoint(3, 3)) != 'x' || clone.Get(NewPoint(1, 1)) != 'x' {
+		t.Errorf("clone lost its writes")
+	}
+}
+
+func TestFlatGridCloneOfCopyIsIndependent(t *testing.T) {
+	g := NewFlatGrid(4, 4, '.')
+	h := g
+	clone := h.Clone()
+
+	p := NewPoint(1, 2)
+	g.Set(p, '#')
+
+	if clone.Get(p) != '.' {
+		t.Errorf("a write through a copy of the cloned grid changed the clone")
+	}
+	if g.Get(p) != '#' {
+		t.Errorf("grid lost its write")
+	}
+}
+
+func TestFlatGridCopiesOfCloneKeepTheirPatches(t *testing.T) {
+	g := NewFlatGrid(4, 4, '.')
+	clone := g.Clone()
+	clone.Set(NewPoint(0, 0), 'a')
+	copied := clone
+
+	clone.Set(NewPoint(1, 0), 'b')
+	copied.Set(NewPoint(1, 0), 'c')
+	copied.Set(NewPoint(0, 0), 'd')
+
+	if clone.Get(NewPoint(1, 0)) != 'b' || clone.Get(NewPoint(0, 0)) != 'a' {
+		t.Errorf("writes to a copy of the clone changed the clone")
+	}
+	if copied.Get(NewPoint(1, 0)) != 'c' || copied.Get(NewPoint(0, 0)) != 'd' {
+		t.Errorf("copy of the clone lost its writes")
+	}
+	if g.Get(NewPoint(0, 0)) != '.' {
+		t.Errorf("writes to the clones changed the original")
+	}
+}
+
+func TestFlatGridZeroValue(t *testing.T) {
+	var g FlatGrid[rune]
+	clone := g.Clone()
+
+	for _, grid := range []*FlatGrid[rune]{&g, &clone} {
+		if grid.Width() != 0 || grid.Height() != 0 || grid.Contains(NewPoint(0, 0)) {
+			t.Errorf("zero value is not an empty grid: %dx%d", grid.Width(), grid.Height())
+		}
+		for p := range grid.Cells() {
+			t.Errorf("zero value has cell %v", p)
+		}
+		assertPanics(t, "Get on the zero value", func() { grid.Get(NewPoint(0, 0)) })
+		assertPanics(t, "Set on the zero value", func() { grid.Set(NewPoint(0, 0), '#') })
+	}
+}
@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"context"
+	"iter"
+	"math/big"
+	"slices"
+
+	"aoc2024/shared"
+)
+
+// ShortestPaths is the shortest path DAG from a start node: the distance to every reached node and all the
+// nodes it is reached from on some shortest path. It answers questions about every shortest path without
+// listing them, since there can be exponentially many.
+type ShortestPaths[N comparable] struct {
+	Start N
+	Dist  map[N]int
+	Prev  map[N][]N
+}
+
+// AllShortestPaths runs Dijkstra from start and keeps every shortest way into each node.
+// Edge costs must be positive, a cycle of free edges would not be a DAG.
+func AllShortestPaths[N comparable](ctx context.Context, g Graph[N], start N) (ShortestPaths[N], error) {
+	result, err := Dijkstra(ctx, g, start, nil)
+	if err != nil {
+		return ShortestPaths[N]{}, err
+	}
+
+	sp := ShortestPaths[N]{Start: start, Dist: result.Dist, Prev: make(map[N][]N)}
+	for current, dist := range result.Dist {
+		if err := ctx.Err(); err != nil {
+			return ShortestPaths[N]{}, err
+		}
+
+		for _, e := range g.Neighbors(current) {
+			if next, ok := result.Dist[e.To]; ok && dist+e.Cost == next {
+				sp.Prev[e.To] = append(sp.Prev[e.To], current)
+			}
+		}
+	}
+	return sp, nil
+}
+
+// NodesOnPaths returns every node on a shortest path from the start to one of the targets.
+func (sp ShortestPaths[N]) NodesOnPaths(targets ...N) *shared.Set[N] {
+	nodes := shared.NewSet[N]()
+
+	var stack []N
+	for _, target := range targets {
+		if _, ok := sp.Dist[target]; ok && !nodes.Contains(target) {
+			nodes.Add(target)
+			stack = append(stack, target)
+		}
+	}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, prev := range sp.Prev[current] {
+			if !nodes.Contains(prev) {
+				nodes.Add(prev)
+				stack = append(stack, prev)
+			}
+		}
+	}
+	return nodes
+}
+
+// CountPaths returns how many shortest paths lead from the start to the targets.
+func (sp ShortestPaths[N]) CountPaths(targets ...N) *big.Int {
+	counts := map[N]*big.Int{sp.Start: big.NewInt(1)}
+
+	var count func(node N) *big.Int
+	count = func(node N) *big.Int {
+		if c, ok := counts[node]; ok {
+			return c
+		}
+
+		c := new(big.Int)
+		for _, prev := range sp.Prev[node] {
+			c.Add(c, count(prev))
+		}
+		counts[node] = c
+		return c
+	}
+
+	total := new(big.Int)
+	for _, target := range targets {
+		if _, ok := sp.Dist[target]; ok {
+			total.Add(total, count(target))
+		}
+	}
+	return total
+}
+
+// Paths yields the shortest paths from the start to the targets one at a time, each as a new slice.
+func (sp ShortestPaths[N]) Paths(targets ...N) iter.Seq[[]N] {
+	return func(yield func([]N) bool) {
+		// walk follows predecessors back to the start, reusing suffix for the part of the path already walked.
+		var walk func(node N, suffix []N) bool
+		walk = func(node N, suffix []N) bool {
+			suffix = append(suffix, node)
+			if node == sp.Start {
+				path := slices.Clone(suffix)
+				slices.Reverse(path)
+				return yield(path)
+			}
+
+			for _, prev := range sp.Prev[node] {
+				if !walk(prev, suffix) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, target := range targets {
+			if _, ok := sp.Dist[target]; !ok {
+				continue
+			}
+			if !walk(target, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"testing"
+
+	"aoc2024/shared"
+)
+
+// lattice is a size x size grid where every step goes right or down, so every monotone path is a shortest path.
+func lattice(size int) Func[shared.Point] {
+	return func(p shared.Point) []Edge[shared.Point] {
+		var edges []Edge[shared.Point]
+		if p.X+1 < size {
+			edges = append(edges, NewEdge(p.Right(), 1))
+		}
+		if p.Y+1 < size {
+			edges = append(edges, NewEdge(p.Down(), 1))
+		}
+		return edges
+	}
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	sp, err := AllShortestPaths(context.Background(), weighted, "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := sp.Prev["c"]; !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Prev[c] = %v, want [b]", got)
+	}
+	if got := sp.NodesOnPaths("d"); got.Size() != 4 || got.Contains("e") {
+		t.Errorf("NodesOnPaths(d) = %v, want a, b, c and d", got)
+	}
+	if got := sp.CountPaths("d", "f"); got.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("CountPaths(d, f) = %v, want 1", got)
+	}
+}
+
+func TestCountPathsBeyondInt64(t *testing.T) {
+	const size = 40
+	sp, err := AllShortestPaths(context.Background(), lattice(size), shared.NewPoint(0, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// choosing which 39 of the 78 steps go down
+	want := new(big.Int).Binomial(2*(size-1), size-1)
+	if got := sp.CountPaths(shared.NewPoint(size-1, size-1)); got.Cmp(want) != 0 {
+		t.Errorf("CountPaths = %v, want %v", got, want)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	sp, err := AllShortestPaths(context.Background(), lattice(3), shared.NewPoint(0, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	corner := shared.NewPoint(2, 2)
+	seen := make(map[string]bool)
+	for path := range sp.Paths(corner) {
+		if len(path) != 5 || path[0] != sp.Start || path[4] != corner {
+			t.Fatalf("path %v does not lead from the start to %v in 4 steps", path, corner)
+		}
+		seen[fmt.Sprint(path)] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("got %d distinct paths, want 6", len(seen))
+	}
+
+	count := 0
+	for range sp.Paths(corner) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("stopped after %d paths, want 2", count)
+	}
+}
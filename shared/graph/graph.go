@@ -0,0 +1,58 @@
+// Package graph provides shortest path searches over generic graphs.
+package graph
+
+import "aoc2024/shared"
+
+// Edge leads to a neighboring node at the given cost.
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+func NewEdge[N comparable](to N, cost int) Edge[N] {
+	return Edge[N]{To: to, Cost: cost}
+}
+
+// Graph lists the outgoing edges of a node.
+type Graph[N comparable] interface {
+	Neighbors(node N) []Edge[N]
+}
+
+// Map is a Graph backed by an adjacency map.
+type Map[N comparable] map[N][]Edge[N]
+
+func (m Map[N]) Neighbors(node N) []Edge[N] {
+	return m[node]
+}
+
+// Reverse returns a graph with every edge pointing the other way.
+func (m Map[N]) Reverse() Map[N] {
+	reversed := make(Map[N], len(m))
+	for from, edges := range m {
+		for _, e := range edges {
+			reversed[e.To] = append(reversed[e.To], NewEdge(from, e.Cost))
+		}
+	}
+	return reversed
+}
+
+// Func is a Graph whose neighbors are computed on demand, such as the open cells around a point of a grid.
+type Func[N comparable] func(node N) []Edge[N]
+
+func (f Func[N]) Neighbors(node N) []Edge[N] {
+	return f(node)
+}
+
+// GridNeighbors is a Graph over the cells of a grid, where every cell is connected to the cardinal neighbors
+// that are not wall, each one step away.
+func GridNeighbors[T comparable](grid shared.Grid[T], wall T) Func[shared.Point] {
+	return func(current shared.Point) []Edge[shared.Point] {
+		var edges []Edge[shared.Point]
+		for _, neighbor := range current.CardinalNeighbors() {
+			if grid.Contains(neighbor) && grid.Get(neighbor) != wall {
+				edges = append(edges, NewEdge(neighbor, 1))
+			}
+		}
+		return edges
+	}
+}
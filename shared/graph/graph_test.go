@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"aoc2024/shared"
+)
+
+// weighted is a small directed graph where the direct edge a->d is more expensive than a->b->c->d.
+var weighted = Map[string]{
+	"a": {NewEdge("b", 1), NewEdge("d", 10), NewEdge("e", 2)},
+	"b": {NewEdge("c", 2)},
+	"c": {NewEdge("d", 3)},
+	"e": {NewEdge("c", 5)},
+	"f": {NewEdge("a", 1)},
+}
+
+// maze is an open grid with a wall that has to be walked around.
+var maze = shared.NewGrid([][]rune{
+	[]rune("....."),
+	[]rune(".###."),
+	[]rune("...#."),
+	[]rune(".#..."),
+})
+
+func is[N comparable](goal N) func(N) bool {
+	return func(n N) bool { return n == goal }
+}
+
+func TestGridNeighbors(t *testing.T) {
+	edges := GridNeighbors(maze, '#').Neighbors(shared.NewPoint(0, 2))
+
+	want := []Edge[shared.Point]{NewEdge(shared.NewPoint(0, 1), 1), NewEdge(shared.NewPoint(1, 2), 1), NewEdge(shared.NewPoint(0, 3), 1)}
+	if len(edges) != len(want) {
+		t.Fatalf("neighbors = %v, want %v", edges, want)
+	}
+	for _, e := range want {
+		if !slices.Contains(edges, e) {
+			t.Errorf("neighbors = %v, missing %v", edges, e)
+		}
+	}
+}
+
+func TestBFS(t *testing.T) {
+	start, goal := shared.NewPoint(0, 0), shared.NewPoint(4, 3)
+
+	r, err := BFS(context.Background(), GridNeighbors(maze, '#'), start, is(goal))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !r.Found || r.Goal != goal {
+		t.Fatalf("goal not found")
+	}
+	if r.Dist[goal] != 7 {
+		t.Errorf("distance = %d, want 7", r.Dist[goal])
+	}
+	if path := r.Path(); len(path) != 8 || path[0] != start || path[7] != goal {
+		t.Errorf("path = %v, want 8 points from %v to %v", path, start, goal)
+	}
+}
+
+func TestBFSCountsSteps(t *testing.T) {
+	r, err := BFS(context.Background(), weighted, "a", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]int{"a": 0, "b": 1, "d": 1, "e": 1, "c": 2}
+	for node, dist := range want {
+		if got, ok := r.Distance(node); !ok || got != dist {
+			t.Errorf("Distance(%s) = %d, %v, want %d", node, got, ok, dist)
+		}
+	}
+	if _, ok := r.Distance("f"); ok {
+		t.Errorf("f is not reachable from a")
+	}
+	if r.PathTo("f") != nil {
+		t.Errorf("PathTo(f) = %v, want nil", r.PathTo("f"))
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	r, err := Dijkstra(context.Background(), weighted, "a", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]int{"a": 0, "b": 1, "e": 2, "c": 3, "d": 6}
+	for node, dist := range want {
+		if got := r.Dist[node]; got != dist {
+			t.Errorf("Dist[%s] = %d, want %d", node, got, dist)
+		}
+	}
+	if path := r.PathTo("d"); !slices.Equal(path, []string{"a", "b", "c", "d"}) {
+		t.Errorf("PathTo(d) = %v, want [a b c d]", path)
+	}
+}
+
+func TestDijkstraQueueWithBuckets(t *testing.T) {
+	r, err := DijkstraQueue(context.Background(), weighted, "a", nil, shared.NewBucketQueue[string]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, _ := Dijkstra(context.Background(), weighted, "a", nil)
+	for node, dist := range d.Dist {
+		if got := r.Dist[node]; got != dist {
+			t.Errorf("Dist[%s] = %d, want %d", node, got, dist)
+		}
+	}
+}
+
+func TestAStar(t *testing.T) {
+	start, goal := shared.NewPoint(0, 0), shared.NewPoint(4, 3)
+	heuristic := func(p shared.Point) int { return shared.ManhattanDistance(p, goal) }
+
+	r, err := AStar(context.Background(), GridNeighbors(maze, '#'), start, is(goal), heuristic)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !r.Found || r.Dist[goal] != 7 {
+		t.Errorf("found %v at distance %d, want distance 7", r.Found, r.Dist[goal])
+	}
+
+	unreachable := shared.NewPoint(2, 1)
+	r, err = AStar(context.Background(), GridNeighbors(maze, '#'), start, is(unreachable), heuristic)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Found || r.Path() != nil {
+		t.Errorf("found a path to the wall at %v", unreachable)
+	}
+}
+
+func TestBidirectional(t *testing.T) {
+	tests := []struct {
+		start    string
+		goal     string
+		want     []string
+		wantCost int
+	}{
+		{start: "a", goal: "d", want: []string{"a", "b", "c", "d"}, wantCost: 6},
+		{start: "f", goal: "c", want: []string{"f", "a", "b", "c"}, wantCost: 4},
+		{start: "b", goal: "b", want: []string{"b"}, wantCost: 0},
+		{start: "d", goal: "a"},
+	}
+
+	for _, tt := range tests {
+		r, err := Bidirectional(context.Background(), weighted, weighted.Reverse(), tt.start, tt.goal)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if r.Found != (tt.want != nil) {
+			t.Errorf("%s->%s: found = %v, want %v", tt.start, tt.goal, r.Found, tt.want != nil)
+			continue
+		}
+		if path := r.Path(); !slices.Equal(path, tt.want) || r.Cost != tt.wantCost {
+			t.Errorf("%s->%s: path %v costing %d, want %v costing %d", tt.start, tt.goal, path, r.Cost, tt.want, tt.wantCost)
+		}
+	}
+}
+
+func TestBidirectionalMatchesDijkstra(t *testing.T) {
+	start, goal := shared.NewPoint(0, 3), shared.NewPoint(4, 0)
+
+	r, err := Bidirectional(context.Background(), GridNeighbors(maze, '#'), GridNeighbors(maze, '#'), start, goal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := Dijkstra(context.Background(), GridNeighbors(maze, '#'), start, is(goal))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Cost != d.Dist[goal] || len(r.Path()) != d.Dist[goal]+1 {
+		t.Errorf("bidirectional path %v costing %d, want cost %d", r.Path(), r.Cost, d.Dist[goal])
+	}
+}
+
+func TestSearchStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Dijkstra(ctx, weighted, "a", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if _, err := BFS(ctx, weighted, "a", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,15 @@
+package graph
+
+import "aoc2024/shared"
+
+// Queue holds the nodes a search has yet to settle, popping the lowest priority first.
+// shared.PriorityQueue works for any costs; shared.BucketQueue is faster when the distances stay small.
+type Queue[N any] interface {
+	Push(node N, priority int)
+	Pop() (N, int, bool)
+	Len() int
+}
+
+func newQueue[N comparable]() Queue[N] {
+	return shared.NewMinPriorityQueue[N]()
+}
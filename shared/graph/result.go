@@ -0,0 +1,49 @@
+package graph
+
+import "slices"
+
+// Result holds what a search learned: the distance to every reached node and the predecessor it was reached from.
+// Found is set when the search stopped at a goal, which is then stored in Goal.
+type Result[N comparable] struct {
+	Start N
+	Dist  map[N]int
+	Prev  map[N]N
+	Goal  N
+	Found bool
+}
+
+func newResult[N comparable](start N) Result[N] {
+	return Result[N]{
+		Start: start,
+		Dist:  map[N]int{start: 0},
+		Prev:  make(map[N]N),
+	}
+}
+
+func (r Result[N]) Distance(node N) (int, bool) {
+	dist, ok := r.Dist[node]
+	return dist, ok
+}
+
+// PathTo returns the nodes from the start to node, or nil if node was not reached.
+func (r Result[N]) PathTo(node N) []N {
+	if _, ok := r.Dist[node]; !ok {
+		return nil
+	}
+
+	path := []N{node}
+	for node != r.Start {
+		node = r.Prev[node]
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Path returns the nodes from the start to the goal, or nil if no goal was found.
+func (r Result[N]) Path() []N {
+	if !r.Found {
+		return nil
+	}
+	return r.PathTo(r.Goal)
+}
@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"aoc2024/shared"
+)
+
+// BFS searches outward from start one edge at a time, ignoring edge costs, so distances count steps.
+// It stops at the first node isGoal accepts; a nil isGoal explores everything reachable.
+func BFS[N comparable](ctx context.Context, g Graph[N], start N, isGoal func(N) bool) (Result[N], error) {
+	r := newResult(start)
+	queue := shared.NewDeque(start)
+
+	for !queue.IsEmpty() {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+
+		current, _ := queue.PopFront()
+
+		if isGoal != nil && isGoal(current) {
+			r.Goal, r.Found = current, true
+			return r, nil
+		}
+
+		for _, e := range g.Neighbors(current) {
+			if _, seen := r.Dist[e.To]; seen {
+				continue
+			}
+
+			r.Dist[e.To] = r.Dist[current] + 1
+			r.Prev[e.To] = current
+			queue.PushBack(e.To)
+		}
+	}
+
+	return r, nil
+}
+
+// Dijkstra finds the cheapest way from start to every node, or to the first node isGoal accepts.
+// Edge costs must not be negative.
+func Dijkstra[N comparable](ctx context.Context, g Graph[N], start N, isGoal func(N) bool) (Result[N], error) {
+	return DijkstraQueue(ctx, g, start, isGoal, newQueue[N]())
+}
+
+// DijkstraQueue is Dijkstra keeping the unsettled nodes in queue, which must be empty.
+func DijkstraQueue[N comparable](ctx context.Context, g Graph[N], start N, isGoal func(N) bool, queue Queue[N]) (Result[N], error) {
+	return aStar(ctx, g, start, isGoal, func(N) int { return 0 }, queue)
+}
+
+// AStar is Dijkstra guided towards the goal by heuristic, an estimate of the remaining cost such as
+// shared.ManhattanDistance to the goal. The heuristic must never overestimate the remaining cost.
+func AStar[N comparable](ctx context.Context, g Graph[N], start N, isGoal func(N) bool, heuristic func(N) int) (Result[N], error) {
+	return aStar(ctx, g, start, isGoal, heuristic, newQueue[N]())
+}
+
+func aStar[N comparable](ctx context.Context, g Graph[N], start N, isGoal func(N) bool, heuristic func(N) int, queue Queue[N]) (Result[N], error) {
+	r := newResult(start)
+	queue.Push(start, heuristic(start))
+
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+
+		current, priority, _ := queue.Pop()
+		dist := r.Dist[current]
+		if priority > dist+heuristic(current) {
+			continue
+		}
+
+		if isGoal != nil && isGoal(current) {
+			r.Goal, r.Found = current, true
+			return r, nil
+		}
+
+		for _, e := range g.Neighbors(current) {
+			alternative := dist + e.Cost
+			if known, ok := r.Dist[e.To]; ok && known <= alternative {
+				continue
+			}
+
+			r.Dist[e.To] = alternative
+			r.Prev[e.To] = current
+			queue.Push(e.To, alternative+heuristic(e.To))
+		}
+	}
+
+	return r, nil
+}
+
+// BidirectionalResult holds the two half searches of Bidirectional and where they met.
+type BidirectionalResult[N comparable] struct {
+	Forward  Result[N]
+	Backward Result[N]
+	Meet     N
+	Cost     int
+	Found    bool
+}
+
+// Path returns the nodes from the start to the goal, or nil if they are not connected.
+func (r BidirectionalResult[N]) Path() []N {
+	if !r.Found {
+		return nil
+	}
+
+	path := r.Forward.PathTo(r.Meet)
+	back := r.Backward.PathTo(r.Meet)
+	slices.Reverse(back)
+	return append(path, back[1:]...)
+}
+
+type frontier[N comparable] struct {
+	graph  Graph[N]
+	result Result[N]
+	queue  *shared.PriorityQueue[N]
+}
+
+func newFrontier[N comparable](g Graph[N], start N) *frontier[N] {
+	f := &frontier[N]{graph: g, result: newResult(start), queue: shared.NewMinPriorityQueue[N]()}
+	f.queue.Push(start, 0)
+	return f
+}
+
+// top returns the smallest distance still waiting to be expanded, dropping outdated entries.
+func (f *frontier[N]) top() int {
+	for {
+		node, priority, ok := f.queue.Peek()
+		if !ok {
+			return math.MaxInt
+		}
+		if priority == f.result.Dist[node] {
+			return priority
+		}
+		f.queue.Pop()
+	}
+}
+
+// expand settles the closest node and returns the nodes whose distance improved.
+func (f *frontier[N]) expand() []N {
+	current, priority, _ := f.queue.Pop()
+
+	var improved []N
+	for _, e := range f.graph.Neighbors(current) {
+		alternative := priority + e.Cost
+		if known, ok := f.result.Dist[e.To]; ok && known <= alternative {
+			continue
+		}
+
+		f.result.Dist[e.To] = alternative
+		f.result.Prev[e.To] = current
+		f.queue.Push(e.To, alternative)
+		improved = append(improved, e.To)
+	}
+	return improved
+}
+
+// Bidirectional runs Dijkstra from start over g and from goal over reverse until the two searches meet.
+// reverse must hold the edges of g pointing the other way, see Map.Reverse; for undirected graphs it is g itself.
+func Bidirectional[N comparable](ctx context.Context, g Graph[N], reverse Graph[N], start N, goal N) (BidirectionalResult[N], error) {
+	forward, backward := newFrontier(g, start), newFrontier(reverse, goal)
+	r := BidirectionalResult[N]{Cost: math.MaxInt}
+
+	if start == goal {
+		r.Meet, r.Cost, r.Found = start, 0, true
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+
+		forwardTop, backwardTop := forward.top(), backward.top()
+		if forwardTop == math.MaxInt || backwardTop == math.MaxInt || forwardTop+backwardTop >= r.Cost {
+			break
+		}
+
+		current, other := forward, backward
+		if backwardTop < forwardTop {
+			current, other = backward, forward
+		}
+
+		for _, node := range current.expand() {
+			otherDist, ok := other.result.Dist[node]
+			if !ok {
+				continue
+			}
+
+			if cost := current.result.Dist[node] + otherDist; cost < r.Cost {
+				r.Meet, r.Cost, r.Found = node, cost, true
+			}
+		}
+	}
+
+	r.Forward, r.Backward = forward.result, backward.result
+	if !r.Found {
+		r.Cost = 0
+	}
+	return r, nil
+}
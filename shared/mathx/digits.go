@@ -0,0 +1,39 @@
+package mathx
+
+// powersOfTen holds every power of ten that fits in an int64.
+var powersOfTen = func() [19]int {
+	var powers [19]int
+	powers[0] = 1
+	for i := 1; i < len(powers); i++ {
+		powers[i] = powers[i-1] * 10
+	}
+	return powers
+}()
+
+// Pow10 returns 10 to the power n, for n from 0 to 18.
+func Pow10(n int) int {
+	return powersOfTen[n]
+}
+
+// DigitCount returns the number of decimal digits of n, ignoring the sign. Zero has one digit.
+func DigitCount(n int) int {
+	n = abs(n)
+	count := 1
+	for count < len(powersOfTen) && n >= powersOfTen[count] {
+		count++
+	}
+	return count
+}
+
+// SplitDigits splits n before its last k digits, so SplitDigits(2024, 2) returns 20 and 24.
+func SplitDigits(n int, k int) (high int, low int) {
+	if k >= len(powersOfTen) {
+		return 0, n
+	}
+	return n / powersOfTen[k], n % powersOfTen[k]
+}
+
+// Concat writes the digits of b after those of a, so Concat(12, 345) returns 12345. b must not be negative.
+func Concat(a int, b int) int {
+	return a*Pow10(DigitCount(b)) + b
+}
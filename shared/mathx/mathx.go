@@ -0,0 +1,169 @@
+// Package mathx provides integer number theory and digit helpers.
+package mathx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	ErrNotCoprime = errors.New("numbers are not coprime")
+	ErrNoSolution = errors.New("congruences have no common solution")
+)
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Mod returns a modulo m in the range [0, m), also for negative a. m must be positive.
+func Mod(a int, m int) int {
+	return (a%m + m) % m
+}
+
+// GCD returns the greatest common divisor of a and b, which is never negative.
+func GCD(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return abs(a)
+}
+
+// LCM returns the least common multiple of the values, or 0 if any of them is 0. Like Pow it returns
+// false when the result does not fit in an int.
+func LCM(values ...int) (int, bool) {
+	lcm := 1
+	for _, v := range values {
+		if v == 0 {
+			return 0, true
+		}
+		product, ok := Mul(lcm/GCD(lcm, v), v)
+		if !ok || product == math.MinInt {
+			return 0, false
+		}
+		lcm = abs(product)
+	}
+	return lcm, true
+}
+
+// ExtendedGCD returns the greatest common divisor g of a and b with coefficients such that a*x + b*y = g.
+func ExtendedGCD(a int, b int) (g int, x int, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// ModInverse returns the x in [0, m) with a*x ≡ 1 (mod m). It fails when a and m share a factor.
+func ModInverse(a int, m int) (int, error) {
+	g, x, _ := ExtendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, fmt.Errorf("inverse of %d modulo %d: %w", a, m, ErrNotCoprime)
+	}
+	return Mod(x, m), nil
+}
+
+// MulMod returns a*b modulo m without overflowing. m must be positive.
+func MulMod(a int, b int, m int) int {
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m)))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// CRT solves the system x ≡ residues[i] (mod moduli[i]) with the Chinese remainder theorem. The moduli
+// need not be coprime. It returns the smallest non-negative solution and the modulus, the LCM of the
+// moduli, after which the solutions repeat.
+func CRT(residues []int, moduli []int) (x int, modulus int, err error) {
+	if len(residues) != len(moduli) {
+		return 0, 0, fmt.Errorf("got %d residues for %d moduli", len(residues), len(moduli))
+	}
+
+	x, modulus = 0, 1
+	for i, m := range moduli {
+		if m <= 0 {
+			return 0, 0, fmt.Errorf("modulus %d is not positive", m)
+		}
+
+		r := Mod(residues[i], m)
+		g, p, _ := ExtendedGCD(modulus, m)
+		if (r-x)%g != 0 {
+			return 0, 0, fmt.Errorf("x ≡ %d (mod %d) and x ≡ %d (mod %d): %w", x, modulus, r, m, ErrNoSolution)
+		}
+
+		// x + modulus*k ≡ r (mod m) gives k ≡ (r-x)/g * p (mod m/g), where p inverts modulus/g modulo m/g.
+		step := m / g
+		k := MulMod((r-x)/g, p, step)
+		next, ok := Mul(modulus, step)
+		if !ok {
+			return 0, 0, fmt.Errorf("the combined modulus of %v does not fit in an int", moduli)
+		}
+		x = Mod(x+modulus*k, next)
+		modulus = next
+	}
+	return x, modulus, nil
+}
+
+// Pow returns base raised to the power exp, or false if the result does not fit in an int.
+// exp must not be negative.
+func Pow(base int, exp int) (int, bool) {
+	if exp < 0 {
+		panic("mathx: negative exponent")
+	}
+
+	result := 1
+	ok := true
+	for exp > 0 && ok {
+		if exp&1 == 1 {
+			result, ok = Mul(result, base)
+		}
+		exp >>= 1
+		if exp > 0 && ok {
+			base, ok = Mul(base, base)
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	return result, true
+}
+
+// Mul returns a*b, or false if the product does not fit in an int.
+func Mul(a int, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return product, true
+}
+
+// ISqrt returns the largest integer whose square is at most n. n must not be negative.
+func ISqrt(n int) int {
+	if n < 0 {
+		panic("mathx: square root of a negative number")
+	}
+
+	// The float estimate can be off by one for large n, so correct it with divisions that cannot overflow.
+	r := int(math.Sqrt(float64(n)))
+	for r > 0 && r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
@@ -0,0 +1,170 @@
+package mathx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGCDAndLCM(t *testing.T) {
+	tests := []struct {
+		a, b     int
+		gcd, lcm int
+	}{
+		{a: 12, b: 18, gcd: 6, lcm: 36},
+		{a: -4, b: 6, gcd: 2, lcm: 12},
+		{a: 0, b: 5, gcd: 5, lcm: 0},
+		{a: 101, b: 103, gcd: 1, lcm: 10403},
+	}
+
+	for _, tt := range tests {
+		if got := GCD(tt.a, tt.b); got != tt.gcd {
+			t.Errorf("GCD(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.gcd)
+		}
+		if got, ok := LCM(tt.a, tt.b); got != tt.lcm || !ok {
+			t.Errorf("LCM(%d, %d) = %d, %t, want %d, true", tt.a, tt.b, got, ok, tt.lcm)
+		}
+	}
+
+	if got, ok := LCM(2, 3, 4, 5); got != 60 || !ok {
+		t.Errorf("LCM(2, 3, 4, 5) = %d, %t, want 60, true", got, ok)
+	}
+}
+
+func TestLCMOverflow(t *testing.T) {
+	tests := [][]int{
+		{math.MaxInt, math.MaxInt - 1},
+		{1000003, 1000033, 1000037, 1000039},
+		{math.MinInt, 1},
+		{1 << 62, 3},
+	}
+
+	for _, values := range tests {
+		if got, ok := LCM(values...); ok {
+			t.Errorf("LCM(%v) = %d, want an overflow", values, got)
+		}
+	}
+
+	if got, ok := LCM(1<<62, 1<<61); got != 1<<62 || !ok {
+		t.Errorf("LCM(2^62, 2^61) = %d, %t, want 2^62, true", got, ok)
+	}
+}
+
+func TestExtendedGCD(t *testing.T) {
+	for _, pair := range [][2]int{{240, 46}, {-7, 3}, {17, 0}, {0, -9}} {
+		a, b := pair[0], pair[1]
+		g, x, y := ExtendedGCD(a, b)
+		if g != GCD(a, b) || a*x+b*y != g {
+			t.Errorf("ExtendedGCD(%d, %d) = %d, %d, %d, which does not satisfy a*x + b*y = gcd", a, b, g, x, y)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	inverse, err := ModInverse(101, 103)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if 101*inverse%103 != 1 {
+		t.Errorf("ModInverse(101, 103) = %d, which is not an inverse", inverse)
+	}
+
+	if got, _ := ModInverse(-3, 7); got != 2 {
+		t.Errorf("ModInverse(-3, 7) = %d, want 2", got)
+	}
+	if _, err := ModInverse(4, 6); !errors.Is(err, ErrNotCoprime) {
+		t.Errorf("ModInverse(4, 6) error = %v, want %v", err, ErrNotCoprime)
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		residues, moduli []int
+		want, modulus    int
+	}{
+		{residues: []int{2, 3, 2}, moduli: []int{3, 5, 7}, want: 23, modulus: 105},
+		{residues: []int{-1, 4}, moduli: []int{101, 103}, want: 4948, modulus: 10403},
+		{residues: []int{3, 1}, moduli: []int{4, 6}, want: 7, modulus: 12},
+		{residues: []int{1_000_000_006, 5}, moduli: []int{1_000_000_007, 998_244_353}, want: 968751683781261738, modulus: 998244359987710471},
+	}
+
+	for _, tt := range tests {
+		got, modulus, err := CRT(tt.residues, tt.moduli)
+		if err != nil {
+			t.Errorf("CRT(%v, %v) failed: %v", tt.residues, tt.moduli, err)
+			continue
+		}
+		if got != tt.want || modulus != tt.modulus {
+			t.Errorf("CRT(%v, %v) = %d mod %d, want %d mod %d", tt.residues, tt.moduli, got, modulus, tt.want, tt.modulus)
+		}
+		for i, m := range tt.moduli {
+			if Mod(got, m) != Mod(tt.residues[i], m) {
+				t.Errorf("CRT(%v, %v) = %d, which is not %d modulo %d", tt.residues, tt.moduli, got, tt.residues[i], m)
+			}
+		}
+	}
+
+	if _, _, err := CRT([]int{1, 2}, []int{4, 6}); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("CRT of incompatible congruences error = %v, want %v", err, ErrNoSolution)
+	}
+}
+
+func TestPow(t *testing.T) {
+	tests := []struct {
+		base, exp int
+		want      int
+		ok        bool
+	}{
+		{base: 2, exp: 10, want: 1024, ok: true},
+		{base: -3, exp: 3, want: -27, ok: true},
+		{base: 7, exp: 0, want: 1, ok: true},
+		{base: 2, exp: 62, want: 1 << 62, ok: true},
+		{base: 2, exp: 63, ok: false},
+		{base: 10, exp: 18, want: 1e18, ok: true},
+		{base: 10, exp: 19, ok: false},
+		{base: -1, exp: 1 << 40, want: 1, ok: true},
+	}
+
+	for _, tt := range tests {
+		if got, ok := Pow(tt.base, tt.exp); got != tt.want || ok != tt.ok {
+			t.Errorf("Pow(%d, %d) = %d, %v, want %d, %v", tt.base, tt.exp, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if _, ok := Mul(math.MinInt, -1); ok {
+		t.Errorf("Mul(MinInt, -1) did not report the overflow")
+	}
+}
+
+func TestISqrt(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 4, 15, 16, 17, 1<<62 - 1, 1 << 62, math.MaxInt} {
+		r := ISqrt(n)
+		if r < 0 || r > n/max(r, 1) || (r+1) <= n/(r+1) {
+			t.Errorf("ISqrt(%d) = %d, which is not the floor of the square root", n, r)
+		}
+	}
+	if got := ISqrt(99); got != 9 {
+		t.Errorf("ISqrt(99) = %d, want 9", got)
+	}
+}
+
+func TestDigits(t *testing.T) {
+	for n, want := range map[int]int{0: 1, 7: 1, 10: 2, -999: 3, 2024: 4, math.MaxInt: 19} {
+		if got := DigitCount(n); got != want {
+			t.Errorf("DigitCount(%d) = %d, want %d", n, got, want)
+		}
+	}
+
+	if high, low := SplitDigits(2024, 2); high != 20 || low != 24 {
+		t.Errorf("SplitDigits(2024, 2) = %d, %d, want 20, 24", high, low)
+	}
+	if high, low := SplitDigits(1000, 2); high != 10 || low != 0 {
+		t.Errorf("SplitDigits(1000, 2) = %d, %d, want 10, 0", high, low)
+	}
+	if got := Concat(12, 345); got != 12345 {
+		t.Errorf("Concat(12, 345) = %d, want 12345", got)
+	}
+	if got := Concat(5, 0); got != 50 {
+		t.Errorf("Concat(5, 0) = %d, want 50", got)
+	}
+}
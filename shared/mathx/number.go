@@ -0,0 +1,95 @@
+package mathx
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// Number is an integer for counts that can grow without bound. It uses overflow-checked int arithmetic
+// while the value fits and moves to math/big when it would not, so results are exact either way.
+// The zero value is 0.
+type Number struct {
+	small int
+	big   *big.Int // set only when the value does not fit in an int
+}
+
+func NewNumber(n int) Number {
+	return Number{small: n}
+}
+
+// NewBigNumber creates a Number from a big.Int, keeping it as an int when it fits.
+func NewBigNumber(n *big.Int) Number {
+	if n.IsInt64() {
+		if v := n.Int64(); int64(int(v)) == v {
+			return Number{small: int(v)}
+		}
+	}
+	return Number{big: new(big.Int).Set(n)}
+}
+
+// Add returns a checked int sum of a and b. It fails when the sum overflows.
+func Add(a int, b int) (int, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+func (n Number) Add(other Number) Number {
+	if n.big == nil && other.big == nil {
+		if sum, ok := Add(n.small, other.small); ok {
+			return Number{small: sum}
+		}
+	}
+	return NewBigNumber(new(big.Int).Add(n.BigInt(), other.BigInt()))
+}
+
+func (n Number) Mul(other Number) Number {
+	if n.big == nil && other.big == nil {
+		if product, ok := Mul(n.small, other.small); ok {
+			return Number{small: product}
+		}
+	}
+	return NewBigNumber(new(big.Int).Mul(n.BigInt(), other.BigInt()))
+}
+
+// Int returns the value if it fits in an int.
+func (n Number) Int() (int, bool) {
+	return n.small, n.big == nil
+}
+
+// IsBig reports whether the value has outgrown an int.
+func (n Number) IsBig() bool {
+	return n.big != nil
+}
+
+// BigInt returns the value as a new big.Int.
+func (n Number) BigInt() *big.Int {
+	if n.big != nil {
+		return new(big.Int).Set(n.big)
+	}
+	return big.NewInt(int64(n.small))
+}
+
+// Cmp compares n and other and returns -1, 0 or +1.
+func (n Number) Cmp(other Number) int {
+	if n.big == nil && other.big == nil {
+		switch {
+		case n.small < other.small:
+			return -1
+		case n.small > other.small:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return n.BigInt().Cmp(other.BigInt())
+}
+
+func (n Number) String() string {
+	if n.big != nil {
+		return n.big.String()
+	}
+	return strconv.Itoa(n.small)
+}
@@ -0,0 +1,58 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	if got, ok := Add(2, 3); got != 5 || !ok {
+		t.Errorf("Add(2, 3) = %d, %v, want 5, true", got, ok)
+	}
+	if _, ok := Add(math.MaxInt, 1); ok {
+		t.Errorf("Add(MaxInt, 1) did not report the overflow")
+	}
+	if _, ok := Add(math.MinInt, -1); ok {
+		t.Errorf("Add(MinInt, -1) did not report the overflow")
+	}
+}
+
+func TestNumberOverflowsIntoBig(t *testing.T) {
+	n := NewNumber(math.MaxInt)
+	sum := n.Add(NewNumber(1))
+	if !sum.IsBig() {
+		t.Fatalf("MaxInt + 1 = %v stayed an int", sum)
+	}
+
+	want := new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1))
+	if sum.BigInt().Cmp(want) != 0 {
+		t.Errorf("MaxInt + 1 = %v, want %v", sum, want)
+	}
+
+	// Coming back into range turns the value back into an int.
+	back := sum.Add(NewNumber(-2))
+	if v, ok := back.Int(); !ok || v != math.MaxInt-1 {
+		t.Errorf("MaxInt + 1 - 2 = %v, %v, want %d as an int", back, ok, math.MaxInt-1)
+	}
+}
+
+func TestNumberMul(t *testing.T) {
+	// 2^100 computed by repeated doubling.
+	n := NewNumber(1)
+	for range 100 {
+		n = n.Mul(NewNumber(2))
+	}
+
+	if got, want := n.String(), "1267650600228229401496703205376"; got != want {
+		t.Errorf("2^100 = %s, want %s", got, want)
+	}
+	if n.Cmp(NewNumber(math.MaxInt)) != 1 || NewNumber(3).Cmp(NewNumber(4)) != -1 || NewNumber(4).Cmp(NewNumber(4)) != 0 {
+		t.Errorf("Cmp orders numbers wrongly")
+	}
+
+	var zero Number
+	if zero.Add(NewNumber(7)).String() != "7" {
+		t.Errorf("the zero Number is not 0")
+	}
+}
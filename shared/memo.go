@@ -0,0 +1,109 @@
+package shared
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+)
+
+// Memo caches the results of a recursive function. The function receives a recurse callback that goes
+// through the cache, so every level of the recursion is memoized. Keys can be any comparable type,
+// including structs that combine several arguments.
+type Memo[K comparable, V any] struct {
+	fn      func(key K, recurse func(K) V) V
+	limit   int
+	entries map[K]*list.Element
+	order   *list.List
+	stats   MemoStats
+}
+
+type memoEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// MemoStats counts how often a Memo found a cached result.
+type MemoStats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+func (s MemoStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func (s MemoStats) String() string {
+	return fmt.Sprintf("%d hits, %d misses, %d evictions (%.1f%% hit rate)", s.Hits, s.Misses, s.Evictions, 100*s.HitRate())
+}
+
+// NewMemo creates a Memo that keeps every result.
+func NewMemo[K comparable, V any](fn func(key K, recurse func(K) V) V) *Memo[K, V] {
+	return NewBoundedMemo(0, fn)
+}
+
+// NewBoundedMemo creates a Memo that keeps at most limit results, evicting the least recently used one
+// when it is full. A limit of 0 or less keeps every result.
+func NewBoundedMemo[K comparable, V any](limit int, fn func(key K, recurse func(K) V) V) *Memo[K, V] {
+	return &Memo[K, V]{fn: fn, limit: limit, entries: make(map[K]*list.Element), order: list.New()}
+}
+
+// Get returns the cached result for key, computing it first if needed.
+func (m *Memo[K, V]) Get(key K) V {
+	if element, ok := m.entries[key]; ok {
+		m.stats.Hits++
+		if m.limit > 0 {
+			m.order.MoveToFront(element)
+		}
+		return element.Value.(memoEntry[K, V]).value
+	}
+
+	m.stats.Misses++
+	value := m.fn(key, m.Get)
+
+	// The recursion may already have stored this key.
+	if element, ok := m.entries[key]; ok {
+		m.order.Remove(element)
+	}
+	m.entries[key] = m.order.PushFront(memoEntry[K, V]{key: key, value: value})
+
+	if m.limit > 0 && m.order.Len() > m.limit {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(memoEntry[K, V]).key)
+		m.stats.Evictions++
+	}
+	return value
+}
+
+func (m *Memo[K, V]) Len() int {
+	return len(m.entries)
+}
+
+func (m *Memo[K, V]) Stats() MemoStats {
+	return m.stats
+}
+
+// Reset drops every cached result and the statistics.
+func (m *Memo[K, V]) Reset() {
+	clear(m.entries)
+	m.order.Init()
+	m.stats = MemoStats{}
+}
+
+type memoReporterKey struct{}
+
+// WithMemoReporter returns a context that passes the statistics given to ReportMemo to report.
+func WithMemoReporter(ctx context.Context, report func(name string, stats MemoStats)) context.Context {
+	return context.WithValue(ctx, memoReporterKey{}, report)
+}
+
+// ReportMemo hands the statistics of a memo to the reporter of ctx, if it has one.
+func ReportMemo(ctx context.Context, name string, stats MemoStats) {
+	if report, ok := ctx.Value(memoReporterKey{}).(func(string, MemoStats)); ok {
+		report(name, stats)
+	}
+}
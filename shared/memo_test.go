@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"context"
+	"testing"
+)
+
+func fibonacci(n int, recurse func(int) int) int {
+	if n < 2 {
+		return n
+	}
+	return recurse(n-1) + recurse(n-2)
+}
+
+func TestMemoRecursion(t *testing.T) {
+	memo := NewMemo(fibonacci)
+
+	if got := memo.Get(80); got != 23416728348467685 {
+		t.Errorf("Get(80) = %d, want 23416728348467685", got)
+	}
+
+	want := MemoStats{Hits: 78, Misses: 81}
+	if got := memo.Stats(); got != want {
+		t.Errorf("Stats() = %v, want %v", got, want)
+	}
+	if memo.Len() != 81 {
+		t.Errorf("Len() = %d, want 81", memo.Len())
+	}
+}
+
+func TestMemoStructKeys(t *testing.T) {
+	type key struct {
+		n, k int
+	}
+	binomial := NewMemo(func(c key, recurse func(key) int) int {
+		if c.k == 0 || c.k == c.n {
+			return 1
+		}
+		return recurse(key{c.n - 1, c.k - 1}) + recurse(key{c.n - 1, c.k})
+	})
+
+	if got := binomial.Get(key{30, 15}); got != 155117520 {
+		t.Errorf("C(30, 15) = %d, want 155117520", got)
+	}
+}
+
+func TestBoundedMemoEvictsLeastRecentlyUsed(t *testing.T) {
+	calls := 0
+	memo := NewBoundedMemo(2, func(n int, _ func(int) int) int {
+		calls++
+		return n * n
+	})
+
+	memo.Get(1)
+	memo.Get(2)
+	memo.Get(1)
+	memo.Get(3) // evicts 2, the least recently used
+
+	if memo.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", memo.Len())
+	}
+
+	memo.Get(1)
+	if calls != 3 {
+		t.Errorf("1 was recomputed after being used recently")
+	}
+	memo.Get(2)
+	if calls != 4 {
+		t.Errorf("2 was still cached after being evicted")
+	}
+
+	want := MemoStats{Hits: 2, Misses: 4, Evictions: 2}
+	if got := memo.Stats(); got != want {
+		t.Errorf("Stats() = %v, want %v", got, want)
+	}
+}
+
+func TestReportMemo(t *testing.T) {
+	ReportMemo(context.Background(), "unreported", MemoStats{})
+
+	var reported []string
+	ctx := WithMemoReporter(context.Background(), func(name string, stats MemoStats) {
+		reported = append(reported, name+": "+stats.String())
+	})
+	ReportMemo(ctx, "test", MemoStats{Hits: 3, Misses: 1})
+
+	want := "test: 3 hits, 1 misses, 0 evictions (75.0% hit rate)"
+	if len(reported) != 1 || reported[0] != want {
+		t.Errorf("reported %q, want %q", reported, want)
+	}
+}
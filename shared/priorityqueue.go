@@ -0,0 +1,225 @@
+package shared
+
+// PriorityQueueEntry is a value waiting in a PriorityQueue. The entry returned by Insert is a handle for
+// changing the priority of the value later.
+type PriorityQueueEntry[T any] struct {
+	Value    T
+	Priority int
+	index    int
+}
+
+// Queued reports whether the entry is still in its queue.
+func (e *PriorityQueueEntry[T]) Queued() bool {
+	return e.index >= 0
+}
+
+// PriorityQueue is a binary heap that pops the entry its comparator orders first.
+type PriorityQueue[T any] struct {
+	entries []*PriorityQueueEntry[T]
+	less    func(a, b *PriorityQueueEntry[T]) bool
+}
+
+// NewMinPriorityQueue creates a queue that pops the lowest priority first.
+func NewMinPriorityQueue[T any]() *PriorityQueue[T] {
+	return NewPriorityQueueFunc(func(a, b *PriorityQueueEntry[T]) bool { return a.Priority < b.Priority })
+}
+
+// NewMaxPriorityQueue creates a queue that pops the highest priority first.
+func NewMaxPriorityQueue[T any]() *PriorityQueue[T] {
+	return NewPriorityQueueFunc(func(a, b *PriorityQueueEntry[T]) bool { return a.Priority > b.Priority })
+}
+
+// NewPriorityQueueFunc creates a queue that pops entries in the order given by less, for example to
+// break ties between equal priorities by value.
+func NewPriorityQueueFunc[T any](less func(a, b *PriorityQueueEntry[T]) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.entries)
+}
+
+func (pq *PriorityQueue[T]) Push(value T, priority int) {
+	pq.Insert(value, priority)
+}
+
+// Insert adds value like Push and returns its entry, which can be passed to Update and Remove.
+func (pq *PriorityQueue[T]) Insert(value T, priority int) *PriorityQueueEntry[T] {
+	entry := &PriorityQueueEntry[T]{Value: value, Priority: priority, index: len(pq.entries)}
+	pq.entries = append(pq.entries, entry)
+	pq.up(entry.index)
+	return entry
+}
+
+// Pop removes the first entry and returns its value and priority, or false if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() (T, int, bool) {
+	if len(pq.entries) == 0 {
+		var zeroValue T
+		return zeroValue, 0, false
+	}
+
+	entry := pq.entries[0]
+	pq.Remove(entry)
+	return entry.Value, entry.Priority, true
+}
+
+// Peek returns the value and priority Pop would return without removing them.
+func (pq *PriorityQueue[T]) Peek() (T, int, bool) {
+	if len(pq.entries) == 0 {
+		var zeroValue T
+		return zeroValue, 0, false
+	}
+	return pq.entries[0].Value, pq.entries[0].Priority, true
+}
+
+// Update changes the priority of a queued entry, such as lowering the distance to a node in Dijkstra.
+// Entries that have already left the queue are ignored.
+func (pq *PriorityQueue[T]) Update(entry *PriorityQueueEntry[T], priority int) {
+	if !entry.Queued() {
+		return
+	}
+	entry.Priority = priority
+	pq.fix(entry.index)
+}
+
+// Remove takes a queued entry out of the queue.
+func (pq *PriorityQueue[T]) Remove(entry *PriorityQueueEntry[T]) {
+	if !entry.Queued() {
+		return
+	}
+
+	i, last := entry.index, len(pq.entries)-1
+	pq.swap(i, last)
+	pq.entries[last] = nil
+	pq.entries = pq.entries[:last]
+	entry.index = -1
+
+	if i < last {
+		pq.fix(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.entries[i], pq.entries[parent]) {
+			return
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the entry at i towards the leaves and reports whether it moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	for {
+		first := 2*i + 1
+		if first >= len(pq.entries) {
+			break
+		}
+		if right := first + 1; right < len(pq.entries) && pq.less(pq.entries[right], pq.entries[first]) {
+			first = right
+		}
+		if !pq.less(pq.entries[first], pq.entries[i]) {
+			break
+		}
+		pq.swap(i, first)
+		i = first
+	}
+	return i > start
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.entries[i], pq.entries[j] = pq.entries[j], pq.entries[i]
+	pq.entries[i].index = i
+	pq.entries[j].index = j
+}
+
+// BucketQueue is a priority queue for integer priorities that stay within a small range of each other,
+// such as the distances in a grid search where every step costs at most a few thousand. It keeps a ring of
+// buckets, one per priority in the range, so pushing is constant time and popping only has to scan past
+// empty buckets. Values with the same priority pop in last in, first out order.
+type BucketQueue[T any] struct {
+	buckets [][]T
+	lowest  int
+	highest int
+	size    int
+}
+
+func NewBucketQueue[T any]() *BucketQueue[T] {
+	return &BucketQueue[T]{buckets: make([][]T, 64)}
+}
+
+func (q *BucketQueue[T]) Len() int {
+	return q.size
+}
+
+func (q *BucketQueue[T]) Push(value T, priority int) {
+	switch {
+	case q.size == 0:
+		q.lowest, q.highest = priority, priority
+	case priority < q.lowest:
+		q.lowest = priority
+	case priority > q.highest:
+		q.highest = priority
+	}
+
+	if span := q.highest - q.lowest + 1; span > len(q.buckets) {
+		q.grow(max(span, 2*len(q.buckets)), priority)
+	}
+
+	i := q.bucket(priority)
+	q.buckets[i] = append(q.buckets[i], value)
+	q.size++
+}
+
+// Pop removes a value with the lowest priority and returns it with its priority, or false if the queue is empty.
+func (q *BucketQueue[T]) Pop() (T, int, bool) {
+	if q.size == 0 {
+		var zeroValue T
+		return zeroValue, 0, false
+	}
+
+	for len(q.buckets[q.bucket(q.lowest)]) == 0 {
+		q.lowest++
+	}
+
+	i := q.bucket(q.lowest)
+	last := len(q.buckets[i]) - 1
+	value := q.buckets[i][last]
+	var zeroValue T
+	q.buckets[i][last] = zeroValue
+	q.buckets[i] = q.buckets[i][:last]
+	q.size--
+	return value, q.lowest, true
+}
+
+func (q *BucketQueue[T]) bucket(priority int) int {
+	return ringIndex(priority, len(q.buckets))
+}
+
+func ringIndex(priority int, size int) int {
+	return (priority%size + size) % size
+}
+
+// grow moves the buckets into a larger ring. Before pushing priority, every queued value was within one
+// ring length of the lowest priority, which tells which priority each old bucket held.
+func (q *BucketQueue[T]) grow(size int, priority int) {
+	oldLowest := q.lowest
+	if priority == q.lowest {
+		oldLowest = q.highest - len(q.buckets) + 1
+	}
+
+	old := q.buckets
+	q.buckets = make([][]T, size)
+	for p := oldLowest; p < oldLowest+len(old); p++ {
+		q.buckets[q.bucket(p)] = old[ringIndex(p, len(old))]
+	}
+}
@@ -0,0 +1,134 @@
+package shared
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type queue[T any] interface {
+	Push(value T, priority int)
+	Pop() (T, int, bool)
+	Len() int
+}
+
+func drain[T any](q queue[T]) []int {
+	var priorities []int
+	for q.Len() > 0 {
+		_, priority, _ := q.Pop()
+		priorities = append(priorities, priority)
+	}
+	return priorities
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	priorities := []int{5, 1, 4, 1, 9, 2, 6}
+
+	tests := []struct {
+		name  string
+		queue queue[string]
+		want  []int
+	}{
+		{name: "min", queue: NewMinPriorityQueue[string](), want: []int{1, 1, 2, 4, 5, 6, 9}},
+		{name: "max", queue: NewMaxPriorityQueue[string](), want: []int{9, 6, 5, 4, 2, 1, 1}},
+		{name: "bucket", queue: NewBucketQueue[string](), want: []int{1, 1, 2, 4, 5, 6, 9}},
+	}
+
+	for _, tt := range tests {
+		for _, p := range priorities {
+			tt.queue.Push("x", p)
+		}
+		if got := drain(tt.queue); !slices.Equal(got, tt.want) {
+			t.Errorf("%s queue popped %v, want %v", tt.name, got, tt.want)
+		}
+		if _, _, ok := tt.queue.Pop(); ok {
+			t.Errorf("%s queue popped from an empty queue", tt.name)
+		}
+	}
+}
+
+func TestPriorityQueueUpdate(t *testing.T) {
+	pq := NewMinPriorityQueue[string]()
+	a := pq.Insert("a", 10)
+	pq.Push("b", 5)
+	c := pq.Insert("c", 7)
+
+	pq.Update(a, 1)
+	pq.Remove(c)
+
+	if value, priority, _ := pq.Pop(); value != "a" || priority != 1 {
+		t.Errorf("Pop() = %s, %d, want a, 1", value, priority)
+	}
+	if a.Queued() || c.Queued() {
+		t.Errorf("entries that left the queue still report being queued")
+	}
+
+	pq.Update(a, 0)
+	if value, _, _ := pq.Pop(); value != "b" || pq.Len() != 0 {
+		t.Errorf("Pop() = %s with %d left, want b with none left", value, pq.Len())
+	}
+}
+
+func TestPriorityQueueFunc(t *testing.T) {
+	pq := NewPriorityQueueFunc(func(a, b *PriorityQueueEntry[string]) bool {
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Value < b.Value
+	})
+	for _, v := range []string{"c", "a", "d", "b"} {
+		pq.Push(v, len(v))
+	}
+	pq.Push("z", 0)
+
+	var got []string
+	for pq.Len() > 0 {
+		v, _, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if want := []string{"z", "a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
+
+func TestQueuesMatchSorting(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for round := range 20 {
+		heap, buckets := NewMinPriorityQueue[int](), NewBucketQueue[int]()
+		var want []int
+
+		// Interleave pushes and pops the way a search does, with priorities that drift upward
+		// and sometimes jump far enough to make the bucket ring grow.
+		base := rng.Intn(100) - 50
+		for range 500 {
+			if len(want) > 0 && rng.Intn(3) == 0 {
+				slices.Sort(want)
+				_, h, _ := heap.Pop()
+				_, b, _ := buckets.Pop()
+				if h != want[0] || b != want[0] {
+					t.Fatalf("round %d: popped %d and %d, want %d", round, h, b, want[0])
+				}
+				base = want[0]
+				want = want[1:]
+				continue
+			}
+
+			p := base + rng.Intn(20)
+			if rng.Intn(50) == 0 {
+				p += rng.Intn(1000) - 200
+			}
+			heap.Push(p, p)
+			buckets.Push(p, p)
+			want = append(want, p)
+		}
+
+		slices.Sort(want)
+		if got := drain[int](heap); !slices.Equal(got, want) {
+			t.Fatalf("round %d: heap drained %v, want %v", round, got, want)
+		}
+		if got := drain[int](buckets); !slices.Equal(got, want) {
+			t.Fatalf("round %d: buckets drained %v, want %v", round, got, want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package shared
+
+// floodFill collects the points connected to start through cardinal steps that stay inside
+// the grid and are accepted by include.
+func floodFill(start Point, contains func(Point) bool, include func(Point) bool) *Set[Point] {
+	filled := NewSet(start)
+	stack := []Point{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, neighbor := range current.CardinalNeighbors() {
+			if filled.Contains(neighbor) || !contains(neighbor) || !include(neighbor) {
+				continue
+			}
+			filled.Add(neighbor)
+			stack = append(stack, neighbor)
+		}
+	}
+	return filled
+}
+
+// components labels the cells of a width x height grid row by row, giving cells the same label
+// when they are connected through neighbors that same considers alike.
+func components[T any](width int, height int, get func(Point) T, same func(a, b T) bool) ([]int, []*Set[Point]) {
+	labels := make([]int, width*height)
+	for i := range labels {
+		labels[i] = -1
+	}
+
+	contains := func(p Point) bool {
+		return p.X >= 0 && p.X < width && p.Y >= 0 && p.Y < height
+	}
+
+	var regions []*Set[Point]
+	for y := range height {
+		for x := range width {
+			if labels[y*width+x] != -1 {
+				continue
+			}
+
+			start := NewPoint(x, y)
+			value := get(start)
+			region := floodFill(start, contains, func(p Point) bool { return same(value, get(p)) })
+
+			for _, p := range region.Items() {
+				labels[p.Y*width+p.X] = len(regions)
+			}
+			regions = append(regions, region)
+		}
+	}
+	return labels, regions
+}
+
+// FloodFill returns start and every point connected to it through cardinal steps onto points include accepts.
+func (g *Grid[T]) FloodFill(start Point, include func(Point) bool) *Set[Point] {
+	return floodFill(start, g.Contains, include)
+}
+
+// Components splits the grid into connected regions of cells that same considers alike. It returns
+// a grid holding the index of the region of every cell, and the regions themselves.
+func (g *Grid[T]) Components(same func(a, b T) bool) (Grid[int], []*Set[Point]) {
+	if len(g.Points) == 0 {
+		return Grid[int]{}, nil
+	}
+
+	width, height := len(g.Points[0]), len(g.Points)
+	flat, regions := components(width, height, g.Get, same)
+
+	labels := make([][]int, height)
+	for y := range labels {
+		labels[y] = flat[y*width : (y+1)*width]
+	}
+	return NewGrid(labels), regions
+}
+
+// FloodFill returns start and every point connected to it through cardinal steps onto points include accepts.
+func (g *FlatGrid[T]) FloodFill(start Point, include func(Point) bool) *Set[Point] {
+	return floodFill(start, g.Contains, include)
+}
+
+// Components splits the grid into connected regions of cells that same considers alike. It returns
+// a grid holding the index of the region of every cell, and the regions themselves.
+func (g *FlatGrid[T]) Components(same func(a, b T) bool) (FlatGrid[int], []*Set[Point]) {
+	labels, regions := components(g.width, g.height, g.Get, same)
+	return newFlatGrid(labels, g.width, g.height), regions
+}
+
+// Perimeter counts the unit edges between the points of region and the points outside it.
+func Perimeter(region *Set[Point]) int {
+	perimeter := 0
+	for p := range region.elements {
+		for _, neighbor := range p.CardinalNeighbors() {
+			if !region.Contains(neighbor) {
+				perimeter++
+			}
+		}
+	}
+	return perimeter
+}
+
+// Sides counts the straight runs of fence around region, including around any holes in it.
+func Sides(region *Set[Point]) int {
+	type fence struct {
+		plot   Point
+		facing Direction
+	}
+
+	fences := NewSet[fence]()
+	for p := range region.elements {
+		for _, dir := range CardinalDirections {
+			if !region.Contains(p.Move(dir, 1)) {
+				fences.Add(fence{plot: p, facing: dir})
+			}
+		}
+	}
+
+	// a side is counted at the fence where it starts, the one with no fence before it going clockwise
+	sides := 0
+	for f := range fences.elements {
+		before := fence{plot: f.plot.Move(f.facing.TurnLeft(), 1), facing: f.facing}
+		if !fences.Contains(before) {
+			sides++
+		}
+	}
+	return sides
+}
+
+// Corners counts the corners of the outline of region, both convex and concave. For a region
+// on a grid every side ends in a corner, so this equals Sides.
+func Corners(region *Set[Point]) int {
+	corners := 0
+	for p := range region.elements {
+		for _, dir := range CardinalDirections {
+			next := dir.TurnRight()
+			a, b := region.Contains(p.Move(dir, 1)), region.Contains(p.Move(next, 1))
+			diagonal := region.Contains(p.Move(dir, 1).Move(next, 1))
+
+			if (!a && !b) || (a && b && !diagonal) {
+				corners++
+			}
+		}
+	}
+	return corners
+}
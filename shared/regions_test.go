@@ -0,0 +1,95 @@
+package shared
+
+import "testing"
+
+func runeGrid(rows ...string) Grid[rune] {
+	points := make([][]rune, len(rows))
+	for i, row := range rows {
+		points[i] = []rune(row)
+	}
+	return NewGrid(points)
+}
+
+func TestFloodFill(t *testing.T) {
+	g := runeGrid(
+		"..#..",
+		"..#..",
+		"###..",
+		".....",
+	)
+
+	open := func(p Point) bool { return g.Get(p) == '.' }
+	if got := g.FloodFill(NewPoint(0, 0), open); got.Size() != 4 {
+		t.Errorf("the walled corner has %d points, want 4", got.Size())
+	}
+	if got := g.FloodFill(NewPoint(4, 0), open); got.Size() != 11 {
+		t.Errorf("the open area has %d points, want 11", got.Size())
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g := runeGrid(
+		"AAAA",
+		"BBCD",
+		"BBCC",
+		"EEEC",
+	)
+
+	labels, regions := g.Components(func(a, b rune) bool { return a == b })
+	if len(regions) != 5 {
+		t.Fatalf("got %d regions, want 5", len(regions))
+	}
+
+	for i, region := range regions {
+		for _, p := range region.Items() {
+			if labels.Get(p) != i {
+				t.Errorf("point %v is in region %d but labeled %d", p, i, labels.Get(p))
+			}
+		}
+	}
+
+	if labels.Get(NewPoint(2, 1)) != labels.Get(NewPoint(3, 3)) {
+		t.Errorf("the C plots are not labeled as one region")
+	}
+
+	flat, err := NewFlatGridFrom(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	flatLabels, flatRegions := flat.Components(func(a, b rune) bool { return a == b })
+	if len(flatRegions) != 5 || flatLabels.Get(NewPoint(1, 2)) != labels.Get(NewPoint(1, 2)) {
+		t.Errorf("flat grid components differ from the grid ones")
+	}
+}
+
+func TestRegionMeasures(t *testing.T) {
+	tests := []struct {
+		name      string
+		grid      Grid[rune]
+		perimeter int
+		sides     int
+	}{
+		{name: "single plot", grid: runeGrid("A"), perimeter: 4, sides: 4},
+		{name: "bar", grid: runeGrid("AAAA"), perimeter: 10, sides: 4},
+		{name: "e-shape", grid: runeGrid("AAAAA", "AXXXX", "AAAAA", "AXXXX", "AAAAA"), perimeter: 36, sides: 12},
+		{name: "holes", grid: runeGrid("AAAAA", "AXAXA", "AAAAA", "AXAXA", "AAAAA"), perimeter: 36, sides: 20},
+		{name: "hole and notch", grid: runeGrid("AAA.", "A.A.", "AAAA", "..AA"), perimeter: 20, sides: 12},
+		{name: "touching holes", grid: runeGrid("AAAAAA", "AAABBA", "AAABBA", "ABBAAA", "ABBAAA", "AAAAAA"), perimeter: 40, sides: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region := tt.grid.FloodFill(NewPoint(0, 0), func(p Point) bool { return tt.grid.Get(p) == 'A' })
+
+			if got := Perimeter(region); got != tt.perimeter {
+				t.Errorf("Perimeter = %d, want %d", got, tt.perimeter)
+			}
+			if got := Sides(region); got != tt.sides {
+				t.Errorf("Sides = %d, want %d", got, tt.sides)
+			}
+			if got := Corners(region); got != tt.sides {
+				t.Errorf("Corners = %d, want %d", got, tt.sides)
+			}
+		})
+	}
+}
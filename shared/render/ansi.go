@@ -0,0 +1,49 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+
+	"aoc2024/shared"
+)
+
+// ANSI writes the cells as text for a terminal with 24-bit color. Palette colors are used for
+// the characters and overlay colors for the background behind them.
+func ANSI[T comparable](w io.Writer, cells Cells[T], opts Options[T]) error {
+	bw := bufio.NewWriter(w)
+	highlights := opts.highlights()
+
+	for y := cells.Min.Y; y <= cells.Max.Y; y++ {
+		for x := cells.Min.X; x <= cells.Max.X; x++ {
+			p := shared.NewPoint(x, y)
+			value := cells.Get(p)
+
+			if c, ok := highlights[p]; ok {
+				bw.WriteString(escape(48, c))
+			}
+			if c, ok := opts.Palette[value]; ok {
+				bw.WriteString(escape(38, c))
+			}
+			bw.WriteString(glyph(value))
+			bw.WriteString("\x1b[0m")
+		}
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+// escape sets the foreground (38) or background (48) color.
+func escape(layer int, c color.Color) string {
+	rgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", layer, rgba.R, rgba.G, rgba.B)
+}
+
+func glyph(value any) string {
+	if r, ok := value.(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(value)
+}
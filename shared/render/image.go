@@ -0,0 +1,85 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"aoc2024/shared"
+)
+
+// Image draws the cells into an image, scaling every cell to a square of Scale pixels.
+func Image[T comparable](cells Cells[T], opts Options[T]) *image.RGBA {
+	scale := opts.scale()
+	img := image.NewRGBA(image.Rect(0, 0, cells.width()*scale, cells.height()*scale))
+	highlights := opts.highlights()
+
+	for y := cells.Min.Y; y <= cells.Max.Y; y++ {
+		for x := cells.Min.X; x <= cells.Max.X; x++ {
+			p := shared.NewPoint(x, y)
+			c, ok := highlights[p]
+			if !ok {
+				c = opts.cellColor(cells.Get(p))
+			}
+
+			px, py := (x-cells.Min.X)*scale, (y-cells.Min.Y)*scale
+			draw.Draw(img, image.Rect(px, py, px+scale, py+scale), image.NewUniform(c), image.Point{}, draw.Src)
+		}
+	}
+	return img
+}
+
+func PNG[T comparable](w io.Writer, cells Cells[T], opts Options[T]) error {
+	return png.Encode(w, Image(cells, opts))
+}
+
+// SVG writes the cells as one square per cell, with the overlays drawn on top.
+func SVG[T comparable](w io.Writer, cells Cells[T], opts Options[T]) error {
+	scale := opts.scale()
+	width, height := cells.width(), cells.height()
+
+	_, err := fmt.Fprintf(w,
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" shape-rendering=\"crispEdges\">\n",
+		width*scale, height*scale, width, height)
+	if err != nil {
+		return err
+	}
+
+	for y := cells.Min.Y; y <= cells.Max.Y; y++ {
+		for x := cells.Min.X; x <= cells.Max.X; x++ {
+			c := opts.cellColor(cells.Get(shared.NewPoint(x, y)))
+			if err := writeRect(w, x-cells.Min.X, y-cells.Min.Y, c); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, overlay := range opts.Overlays {
+		for _, p := range overlay.Points {
+			if p.X < cells.Min.X || p.X > cells.Max.X || p.Y < cells.Min.Y || p.Y > cells.Max.Y {
+				continue
+			}
+			if err := writeRect(w, p.X-cells.Min.X, p.Y-cells.Min.Y, overlay.Color); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = fmt.Fprintln(w, "</svg>")
+	return err
+}
+
+func writeRect(w io.Writer, x int, y int, c color.Color) error {
+	rgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	opacity := ""
+	if rgba.A != 0xff {
+		opacity = fmt.Sprintf(" fill-opacity=\"%.2f\"", float64(rgba.A)/0xff)
+	}
+
+	_, err := fmt.Fprintf(w, "<rect x=\"%d\" y=\"%d\" width=\"1\" height=\"1\" fill=\"#%02x%02x%02x\"%s/>\n",
+		x, y, rgba.R, rgba.G, rgba.B, opacity)
+	return err
+}
@@ -0,0 +1,81 @@
+// Package render draws grids as PNG or SVG images, or as colored text for a terminal.
+package render
+
+import (
+	"image/color"
+
+	"aoc2024/shared"
+)
+
+// Cells is the rectangle of a grid to draw, from Min to Max inclusive.
+type Cells[T any] struct {
+	Min shared.Point
+	Max shared.Point
+	Get func(shared.Point) T
+}
+
+func FromGrid[T any](g shared.Grid[T]) Cells[T] {
+	return Cells[T]{Max: shared.NewPoint(g.MaxX(), g.MaxY()), Get: g.Get}
+}
+
+func FromFlatGrid[T any](g shared.FlatGrid[T]) Cells[T] {
+	return Cells[T]{Max: shared.NewPoint(g.Width()-1, g.Height()-1), Get: g.Get}
+}
+
+// FromSparseGrid draws the bounding box of the cells set in g.
+func FromSparseGrid[T any](g *shared.SparseGrid[T]) Cells[T] {
+	lo, hi, ok := g.Bounds()
+	if !ok {
+		hi = shared.NewPoint(-1, -1)
+	}
+	return Cells[T]{Min: lo, Max: hi, Get: g.Get}
+}
+
+func (c Cells[T]) width() int {
+	return c.Max.X - c.Min.X + 1
+}
+
+func (c Cells[T]) height() int {
+	return c.Max.Y - c.Min.Y + 1
+}
+
+// Overlay highlights a set of points, such as a route or a region, on top of the cells.
+type Overlay struct {
+	Points []shared.Point
+	Color  color.Color
+}
+
+// Options says how to color the cells. Values missing from the palette get the default color,
+// and later overlays are drawn over earlier ones.
+type Options[T comparable] struct {
+	Palette  map[T]color.Color
+	Default  color.Color
+	Overlays []Overlay
+	// Scale is the size of a cell in pixels for images. It defaults to 1.
+	Scale int
+}
+
+func (o Options[T]) scale() int {
+	return max(o.Scale, 1)
+}
+
+func (o Options[T]) cellColor(value T) color.Color {
+	if c, ok := o.Palette[value]; ok {
+		return c
+	}
+	if o.Default != nil {
+		return o.Default
+	}
+	return color.Black
+}
+
+// highlights maps every overlaid point to the color of the topmost overlay covering it.
+func (o Options[T]) highlights() map[shared.Point]color.Color {
+	colors := make(map[shared.Point]color.Color)
+	for _, overlay := range o.Overlays {
+		for _, p := range overlay.Points {
+			colors[p] = overlay.Color
+		}
+	}
+	return colors
+}
@@ -0,0 +1,166 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"aoc2024/shared"
+)
+
+var (
+	wall  = color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff}
+	floor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	route = color.RGBA{R: 0xff, A: 0xff}
+)
+
+func maze() Cells[rune] {
+	return FromGrid(shared.NewGrid([][]rune{
+		[]rune("###"),
+		[]rune("#.#"),
+		[]rune("#.."),
+	}))
+}
+
+func options() Options[rune] {
+	return Options[rune]{
+		Palette:  map[rune]color.Color{'#': wall, '.': floor},
+		Overlays: []Overlay{{Points: []shared.Point{shared.NewPoint(1, 2), shared.NewPoint(2, 2)}, Color: route}},
+		Scale:    2,
+	}
+}
+
+func TestPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PNG(&buf, maze(), options()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 6 || b.Dy() != 6 {
+		t.Fatalf("image is %dx%d, want 6x6", b.Dx(), b.Dy())
+	}
+
+	tests := []struct {
+		x, y int
+		want color.Color
+	}{
+		{x: 0, y: 0, want: wall},
+		{x: 3, y: 3, want: floor},
+		{x: 2, y: 5, want: route},
+		{x: 5, y: 4, want: route},
+	}
+	for _, tt := range tests {
+		if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+			t.Errorf("pixel (%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SVG(&buf, maze(), options()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svg := buf.String()
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("not an svg document: %q", svg)
+	}
+	if got := strings.Count(svg, "<rect"); got != 11 {
+		t.Errorf("got %d rects, want 9 cells and 2 overlay points", got)
+	}
+	if got := strings.Count(svg, `fill="#ff0000"`); got != 2 {
+		t.Errorf("got %d highlighted rects, want 2", got)
+	}
+}
+
+func TestANSI(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ANSI(&buf, maze(), options()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	if !strings.Contains(lines[2], "\x1b[48;2;255;0;0m\x1b[38;2;255;255;255m.") {
+		t.Errorf("highlighted floor not drawn on a red background: %q", lines[2])
+	}
+	if strings.Contains(lines[0], "\x1b[48;") {
+		t.Errorf("unhighlighted row has a background: %q", lines[0])
+	}
+}
+
+func TestFromSparseGrid(t *testing.T) {
+	g := shared.NewSparseGrid('.')
+	g.Set(shared.NewPoint(-2, -1), '#')
+	g.Set(shared.NewPoint(1, 0), '#')
+
+	cells := FromSparseGrid(g)
+	if cells.width() != 4 || cells.height() != 2 {
+		t.Errorf("cells are %dx%d, want 4x2", cells.width(), cells.height())
+	}
+
+	img := Image(cells, Options[rune]{Palette: map[rune]color.Color{'#': wall}, Default: floor})
+	if got := img.At(0, 0); got != wall {
+		t.Errorf("top left pixel = %v, want the wall at (-2, -1)", got)
+	}
+	if got := img.At(1, 0); got != floor {
+		t.Errorf("unset cell = %v, want the default color", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+	if Enabled(ctx) {
+		t.Fatalf("a context without a reporter is enabled")
+	}
+	Report(ctx, "maze", maze(), options())
+
+	var names []string
+	var buf bytes.Buffer
+	ctx = WithReporter(ctx, func(name string, picture Picture) {
+		names = append(names, name)
+		if err := picture(&buf, FormatSVG); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !Enabled(ctx) {
+		t.Fatalf("a context with a reporter is not enabled")
+	}
+	Report(ctx, "maze", maze(), options())
+
+	if len(names) != 1 || names[0] != "maze" {
+		t.Errorf("reported %v, want [maze]", names)
+	}
+	if !strings.HasPrefix(buf.String(), "<svg") {
+		t.Errorf("picture is not an SVG: %q", buf.String())
+	}
+}
+
+func TestDrawUnknownFormat(t *testing.T) {
+	if err := Draw(io.Discard, "bmp", maze(), options()); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
+
+func TestSpectrum(t *testing.T) {
+	colors := Spectrum(6)
+	seen := make(map[color.Color]bool)
+	for _, c := range colors {
+		seen[c] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("Spectrum(6) has %d distinct colors, want 6: %v", len(seen), colors)
+	}
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+)
+
+const (
+	FormatPNG  = "png"
+	FormatSVG  = "svg"
+	FormatANSI = "ansi"
+)
+
+func ValidFormat(format string) bool {
+	return format == FormatPNG || format == FormatSVG || format == FormatANSI
+}
+
+// Draw writes the cells in the given format.
+func Draw[T comparable](w io.Writer, format string, cells Cells[T], opts Options[T]) error {
+	switch format {
+	case FormatPNG:
+		return PNG(w, cells, opts)
+	case FormatSVG:
+		return SVG(w, cells, opts)
+	case FormatANSI:
+		return ANSI(w, cells, opts)
+	default:
+		return fmt.Errorf("unknown render format %q", format)
+	}
+}
+
+// Picture draws something a day wants to show, in the format it is given.
+type Picture func(w io.Writer, format string) error
+
+type reporterKey struct{}
+
+// WithReporter returns a context that passes the pictures given to Report to report.
+func WithReporter(ctx context.Context, report func(name string, picture Picture)) context.Context {
+	return context.WithValue(ctx, reporterKey{}, report)
+}
+
+// Enabled reports whether ctx has a reporter, so a day can skip preparing a picture nobody looks at.
+func Enabled(ctx context.Context) bool {
+	_, ok := ctx.Value(reporterKey{}).(func(string, Picture))
+	return ok
+}
+
+// Report hands a picture of the cells to the reporter of ctx, if it has one.
+func Report[T comparable](ctx context.Context, name string, cells Cells[T], opts Options[T]) {
+	if report, ok := ctx.Value(reporterKey{}).(func(string, Picture)); ok {
+		report(name, func(w io.Writer, format string) error {
+			return Draw(w, format, cells, opts)
+		})
+	}
+}
+
+// Spectrum returns n colors spread around the color wheel, for palettes that only need to tell values apart.
+func Spectrum(n int) []color.Color {
+	colors := make([]color.Color, n)
+	for i := range colors {
+		colors[i] = hue(float64(i) / float64(n))
+	}
+	return colors
+}
+
+// hue converts a hue between 0 and 1 to a bright, slightly muted color.
+func hue(h float64) color.Color {
+	const saturation, value = 0.6, 0.9
+
+	sector := h * 6
+	f := sector - math.Floor(sector)
+	p := value * (1 - saturation)
+	q := value * (1 - saturation*f)
+	t := value * (1 - saturation*(1-f))
+
+	var r, g, b float64
+	switch int(sector) % 6 {
+	case 0:
+		r, g, b = value, t, p
+	case 1:
+		r, g, b = q, value, p
+	case 2:
+		r, g, b = p, value, t
+	case 3:
+		r, g, b = p, q, value
+	case 4:
+		r, g, b = t, p, value
+	default:
+		r, g, b = value, p, q
+	}
+	return color.RGBA{R: uint8(r * 0xff), G: uint8(g * 0xff), B: uint8(b * 0xff), A: 0xff}
+}
@@ -0,0 +1,193 @@
+package shared
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Set is an unordered collection of unique items. The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	elements map[T]struct{}
+}
+
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{elements: make(map[T]struct{}, len(items))}
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+func (s *Set[T]) Add(item T) {
+	if s.elements == nil {
+		s.elements = make(map[T]struct{})
+	}
+	s.elements[item] = struct{}{}
+}
+
+func (s *Set[T]) Remove(item T) {
+	delete(s.elements, item)
+}
+
+func (s *Set[T]) Contains(item T) bool {
+	_, exists := s.elements[item]
+	return exists
+}
+
+func (s *Set[T]) Size() int {
+	return len(s.elements)
+}
+
+// All iterates over the items in no particular order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.elements)
+}
+
+func (s *Set[T]) Items() []T {
+	return slices.AppendSeq(make([]T, 0, len(s.elements)), s.All())
+}
+
+// SortedFunc returns the items ordered by cmp.
+func (s *Set[T]) SortedFunc(cmp func(a, b T) int) []T {
+	return slices.SortedFunc(s.All(), cmp)
+}
+
+// SortedItems returns the items of a set of ordered values in ascending order.
+func SortedItems[T cmp.Ordered](s *Set[T]) []T {
+	return slices.Sorted(s.All())
+}
+
+func (s *Set[T]) Absorb(other *Set[T]) {
+	for item := range other.elements {
+		s.Add(item)
+	}
+}
+
+func (s *Set[T]) Pop() (T, bool) {
+	for item := range s.elements {
+		s.Remove(item)
+		return item, true
+	}
+
+	var zeroValue T
+	return zeroValue, false
+}
+
+func (s *Set[T]) Peek() (T, bool) {
+	for item := range s.elements {
+		return item, true
+	}
+
+	var zeroValue T
+	return zeroValue, false
+}
+
+func (s *Set[T]) Clone() *Set[T] {
+	clone := &Set[T]{elements: make(map[T]struct{}, len(s.elements))}
+	clone.Absorb(s)
+	return clone
+}
+
+// Union returns the items that are in either set.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	union := s.Clone()
+	union.Absorb(other)
+	return union
+}
+
+// Intersection returns the items that are in both sets.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if small.Size() > large.Size() {
+		small, large = large, small
+	}
+
+	intersection := NewSet[T]()
+	for item := range small.elements {
+		if large.Contains(item) {
+			intersection.Add(item)
+		}
+	}
+	return intersection
+}
+
+// Difference returns the items that are in s but not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	diff := NewSet[T]()
+	for item := range s.elements {
+		if !other.Contains(item) {
+			diff.Add(item)
+		}
+	}
+	return diff
+}
+
+// SymmetricDifference returns the items that are in exactly one of the sets.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	diff := s.Difference(other)
+	for item := range other.elements {
+		if !s.Contains(item) {
+			diff.Add(item)
+		}
+	}
+	return diff
+}
+
+// IsSubset reports whether every item of s is also in other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	for item := range s.elements {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Size() == other.Size() && s.IsSubset(other)
+}
+
+// String lists the items sorted by their formatted value, so equal sets print the same.
+func (s *Set[T]) String() string {
+	items := make([]string, 0, s.Size())
+	for item := range s.elements {
+		items = append(items, fmt.Sprintf("%v", item))
+	}
+	slices.Sort(items)
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// MarshalJSON encodes the set as an array, sorted by the encoded items so the output is stable.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	items := make([]json.RawMessage, 0, s.Size())
+	for item := range s.elements {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, encoded)
+	}
+	slices.SortFunc(items, func(a, b json.RawMessage) int { return strings.Compare(string(a), string(b)) })
+	return json.Marshal(items)
+}
+
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	s.elements = make(map[T]struct{}, len(items))
+	for _, item := range items {
+		s.Add(item)
+	}
+	return nil
+}
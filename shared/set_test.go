@@ -0,0 +1,84 @@
+package shared
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want []int
+	}{
+		{name: "union", got: a.Union(b), want: []int{1, 2, 3, 4, 5}},
+		{name: "intersection", got: a.Intersection(b), want: []int{3, 4}},
+		{name: "difference", got: a.Difference(b), want: []int{1, 2}},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: []int{1, 2, 5}},
+	}
+
+	for _, tt := range tests {
+		if got := SortedItems(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if a.Size() != 4 || b.Size() != 3 {
+		t.Errorf("the operands were modified: %v and %v", a, b)
+	}
+}
+
+func TestSetComparisons(t *testing.T) {
+	small := NewSet("a", "b")
+	large := NewSet("a", "b", "c")
+
+	if !small.IsSubset(large) || large.IsSubset(small) {
+		t.Errorf("IsSubset got the containment of %v and %v wrong", small, large)
+	}
+	if !NewSet[string]().IsSubset(small) {
+		t.Errorf("the empty set is not a subset of %v", small)
+	}
+	if !small.Equal(NewSet("b", "a")) || small.Equal(large) {
+		t.Errorf("Equal got the equality of %v wrong", small)
+	}
+}
+
+func TestSetZeroValue(t *testing.T) {
+	var s Set[int]
+	if s.Contains(1) || s.Size() != 0 {
+		t.Fatalf("the zero set is not empty")
+	}
+
+	s.Add(1)
+	if !s.Contains(1) {
+		t.Errorf("the zero set did not keep an added item")
+	}
+}
+
+func TestSetString(t *testing.T) {
+	if got, want := NewSet("c", "a", "b").String(), "{a, b, c}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	data, err := json.Marshal(NewSet(3, 1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "[1,2,3]"; got != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+
+	var decoded Set[int]
+	if err := json.Unmarshal([]byte("[2,2,5]"), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(NewSet(2, 5)) {
+		t.Errorf("Unmarshal = %v, want {2, 5}", &decoded)
+	}
+}
@@ -2,35 +2,32 @@ package shared
 
 import (
 	"bufio"
-	"container/heap"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 )
 
-func ReadFileToString(filename string) (string, error) {
-	data, err := os.ReadFile(filename)
+func ReadToString(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
 	if err != nil {
 		return "", err
 	}
 	return string(data), nil
 }
 
-func ReadFileByLine(filename string) ([]string, error) {
-	file, err := os.Open(filename)
+func ReadFileToString(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	defer func(file *os.File) {
-		cerr := file.Close()
-		if cerr != nil && err == nil {
-			err = cerr
-		}
-	}(file)
+	return string(data), nil
+}
 
+func ReadByLine(r io.Reader) ([]string, error) {
 	var lines []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		lines = append(lines, scanner.Text())
 	}
@@ -41,8 +38,12 @@ func ReadFileByLine(filename string) ([]string, error) {
 	return lines, nil
 }
 
-func ReadFileByLineToInt(filename string) ([]int, error) {
-	lines, err := ReadFileByLine(filename)
+func ReadFileByLine(filename string) ([]string, error) {
+	return readFile(filename, ReadByLine)
+}
+
+func ReadByLineToInt(r io.Reader) ([]int, error) {
+	lines, err := ReadByLine(r)
 	if err != nil {
 		return nil, err
 	}
@@ -59,11 +60,15 @@ func ReadFileByLineToInt(filename string) ([]int, error) {
 	return numbers, nil
 }
 
-func ReadFileByBlankLine(filename string) ([][]string, error) {
+func ReadFileByLineToInt(filename string) ([]int, error) {
+	return readFile(filename, ReadByLineToInt)
+}
+
+func ReadByBlankLine(r io.Reader) ([][]string, error) {
 	var groupedLines [][]string
 	var currentGroup []string
 
-	lines, err := ReadFileByLine(filename)
+	lines, err := ReadByLine(r)
 	if err != nil {
 		return nil, err
 	}
@@ -82,20 +87,13 @@ func ReadFileByBlankLine(filename string) ([][]string, error) {
 	return groupedLines, nil
 }
 
-func ReadFileByLineToSplitInts(filename string, sep string) ([][]int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer func(file *os.File) {
-		cerr := file.Close()
-		if cerr != nil && err == nil {
-			err = cerr
-		}
-	}(file)
+func ReadFileByBlankLine(filename string) ([][]string, error) {
+	return readFile(filename, ReadByBlankLine)
+}
 
+func ReadByLineToSplitInts(r io.Reader, sep string) ([][]int, error) {
 	var lines [][]int
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := scanner.Text()
 		splitLine := strings.Split(line, sep)
@@ -117,8 +115,14 @@ func ReadFileByLineToSplitInts(filename string, sep string) ([][]int, error) {
 	return lines, nil
 }
 
-func ReadFileBySingleIntLine(filename string, sep string) ([]int, error) {
-	lines, err := ReadFileByLineToSplitInts(filename, sep)
+func ReadFileByLineToSplitInts(filename string, sep string) ([][]int, error) {
+	return readFile(filename, func(r io.Reader) ([][]int, error) {
+		return ReadByLineToSplitInts(r, sep)
+	})
+}
+
+func ReadBySingleIntLine(r io.Reader, sep string) ([]int, error) {
+	lines, err := ReadByLineToSplitInts(r, sep)
 	if err != nil {
 		return nil, err
 	}
@@ -130,8 +134,14 @@ func ReadFileBySingleIntLine(filename string, sep string) ([]int, error) {
 	return lines[0], nil
 }
 
-func ReadFileToRuneGrid(filename string) (Grid[rune], error) {
-	lines, err := ReadFileByLine(filename)
+func ReadFileBySingleIntLine(filename string, sep string) ([]int, error) {
+	return readFile(filename, func(r io.Reader) ([]int, error) {
+		return ReadBySingleIntLine(r, sep)
+	})
+}
+
+func ReadToRuneGrid(r io.Reader) (Grid[rune], error) {
+	lines, err := ReadByLine(r)
 	if err != nil {
 		return Grid[rune]{}, err
 	}
@@ -144,25 +154,43 @@ func ReadFileToRuneGrid(filename string) (Grid[rune], error) {
 	return NewGrid(grid), nil
 }
 
+func ReadFileToRuneGrid(filename string) (Grid[rune], error) {
+	return readFile(filename, ReadToRuneGrid)
+}
+
+func ReadToRuneGridWithStartingPoint(r io.Reader, marker rune) (Grid[rune], Point, error) {
+	grid, err := ReadToRuneGrid(r)
+	if err != nil {
+		return Grid[rune]{}, Point{}, err
+	}
+
+	startingPoint := findMarker(grid, marker)
+	return grid, startingPoint, nil
+}
+
 func ReadFileToRuneGridWithStartingPoint(filename string, marker rune) (Grid[rune], Point, error) {
-	lines, err := ReadFileByLine(filename)
+	grid, err := ReadFileToRuneGrid(filename)
 	if err != nil {
 		return Grid[rune]{}, Point{}, err
 	}
 
-	var startingPoint Point
+	startingPoint := findMarker(grid, marker)
+	return grid, startingPoint, nil
+}
 
-	grid := make([][]rune, len(lines))
-	for i, line := range lines {
-		row := []rune(line)
-		grid[i] = row
-		for j, char := range row {
-			if char == marker {
-				startingPoint = NewPoint(j, i)
-			}
-		}
+func ReadToRuneGridWithStartingPointAndGoal(
+	r io.Reader,
+	startMarker rune,
+	goalMarker rune,
+) (Grid[rune], Point, Point, error) {
+	grid, err := ReadToRuneGrid(r)
+	if err != nil {
+		return Grid[rune]{}, Point{}, Point{}, err
 	}
-	return NewGrid(grid), startingPoint, nil
+
+	startingPoint := findMarker(grid, startMarker)
+	goal := findMarker(grid, goalMarker)
+	return grid, startingPoint, goal, nil
 }
 
 func ReadFileToRuneGridWithStartingPointAndGoal(
@@ -170,32 +198,18 @@ func ReadFileToRuneGridWithStartingPointAndGoal(
 	startMarker rune,
 	goalMarker rune,
 ) (Grid[rune], Point, Point, error) {
-	lines, err := ReadFileByLine(filename)
+	grid, err := ReadFileToRuneGrid(filename)
 	if err != nil {
 		return Grid[rune]{}, Point{}, Point{}, err
 	}
 
-	var startingPoint Point
-	var goal Point
-
-	grid := make([][]rune, len(lines))
-	for i, line := range lines {
-		row := []rune(line)
-		grid[i] = row
-		for j, char := range row {
-			if char == startMarker {
-				startingPoint = NewPoint(j, i)
-			}
-			if char == goalMarker {
-				goal = NewPoint(j, i)
-			}
-		}
-	}
-	return NewGrid(grid), startingPoint, goal, nil
+	startingPoint := findMarker(grid, startMarker)
+	goal := findMarker(grid, goalMarker)
+	return grid, startingPoint, goal, nil
 }
 
-func ReadFileToIntGrid(filename string) (Grid[int], error) {
-	lines, err := ReadFileByLine(filename)
+func ReadToIntGrid(r io.Reader) (Grid[int], error) {
+	lines, err := ReadByLine(r)
 	if err != nil {
 		return Grid[int]{}, err
 	}
@@ -216,94 +230,35 @@ func ReadFileToIntGrid(filename string) (Grid[int], error) {
 	return NewGrid(grid), nil
 }
 
-type Set[T comparable] struct {
-	elements map[T]struct{}
-}
-
-func NewSet[T comparable](items ...T) *Set[T] {
-	s := &Set[T]{elements: make(map[T]struct{})}
-	for _, item := range items {
-		s.Add(item)
-	}
-	return s
-}
-
-func (s *Set[T]) Add(item T) {
-	s.elements[item] = struct{}{}
-}
-
-func (s *Set[T]) Remove(item T) {
-	delete(s.elements, item)
-}
-
-func (s *Set[T]) Contains(item T) bool {
-	_, exists := s.elements[item]
-	return exists
-}
-
-func (s *Set[T]) Size() int {
-	return len(s.elements)
-}
-
-func (s *Set[T]) Items() []T {
-	items := make([]T, 0, len(s.elements))
-	for item := range s.elements {
-		items = append(items, item)
-	}
-	return items
-}
-
-func (s *Set[T]) Absorb(other *Set[T]) {
-	for _, item := range other.Items() {
-		s.Add(item)
-	}
-}
-
-func (s *Set[T]) Pop() (T, bool) {
-	for item := range s.elements {
-		s.Remove(item)
-		return item, true
-	}
-
-	var zeroValue T
-	return zeroValue, false
-}
-
-func (s *Set[T]) Peek() (T, bool) {
-	for item := range s.elements {
-		return item, true
-	}
-
-	var zeroValue T
-	return zeroValue, false
-}
-
-func (s *Set[T]) Clone() *Set[T] {
-	clone := NewSet[T]()
-	clone.Absorb(s)
-	return clone
+func ReadFileToIntGrid(filename string) (Grid[int], error) {
+	return readFile(filename, ReadToIntGrid)
 }
 
-func (s *Set[T]) Difference(other Set[T]) *Set[T] {
-	diff := NewSet[T]()
-	for item := range s.elements {
-		if !other.Contains(item) {
-			diff.Add(item)
+func findMarker(grid Grid[rune], marker rune) Point {
+	var markerPoint Point
+	for y, row := range grid.Rows() {
+		for x, char := range row {
+			if char == marker {
+				markerPoint = NewPoint(x, y)
+			}
 		}
 	}
-	return diff
+	return markerPoint
 }
 
-func (s Set[T]) String() string {
-	var sb strings.Builder
-	sb.WriteString("{")
-
-	for item := range s.elements {
-		sb.WriteString(fmt.Sprintf("%v, ", item))
+func readFile[T any](filename string, read func(io.Reader) (T, error)) (result T, err error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return result, err
 	}
+	defer func(file *os.File) {
+		cerr := file.Close()
+		if cerr != nil && err == nil {
+			err = cerr
+		}
+	}(file)
 
-	sb.WriteString("}")
-	return sb.String()
+	return read(file)
 }
 
 func AbsInt(x int) int {
@@ -360,6 +315,18 @@ func (p Point) CardinalNeighbors() []Point {
 	return []Point{p.Left(), p.Right(), p.Up(), p.Down()}
 }
 
+// Wrap folds p into a width x height room whose edges are joined, so that stepping off one side comes back on the other.
+func (p Point) Wrap(width int, height int) Point {
+	x, y := p.X%width, p.Y%height
+	if x < 0 {
+		x += width
+	}
+	if y < 0 {
+		y += height
+	}
+	return NewPoint(x, y)
+}
+
 func ManhattanDistance(p1 Point, p2 Point) int {
 	return AbsInt(p1.X-p2.X) + AbsInt(p1.Y-p2.Y)
 }
@@ -482,177 +449,3 @@ func (s *Stack[T]) Pop() (T, bool) {
 	s.elements = s.elements[:maxIndex]
 	return item, true
 }
-
-type PriorityQueueEntry[T any] struct {
-	Value    T
-	Priority int
-	index    int
-}
-
-func NewPriorityQueueEntry[T any](value T, priority int) PriorityQueueEntry[T] {
-	return PriorityQueueEntry[T]{Value: value, Priority: priority}
-}
-
-type PriorityQueue[T any] []*PriorityQueueEntry[T]
-
-func NewPriorityQueue[T any]() PriorityQueue[T] {
-	return make(PriorityQueue[T], 0)
-}
-
-func (pq PriorityQueue[T]) Len() int {
-	return len(pq)
-}
-
-func (pq PriorityQueue[T]) Less(i, j int) bool {
-	return pq[i].Priority > pq[j].Priority
-}
-
-func (pq PriorityQueue[T]) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-	pq[i].index = i
-	pq[j].index = j
-}
-
-func (pq *PriorityQueue[T]) Push(x interface{}) {
-	n := len(*pq)
-	item, ok := x.(*PriorityQueueEntry[T])
-	if !ok {
-		panic("Push: type assertion to *Item[T] failed")
-	}
-	item.index = n
-	*pq = append(*pq, item)
-}
-
-func (pq *PriorityQueue[T]) Pop() interface{} {
-	old := *pq
-	n := len(old)
-	if n == 0 {
-		return nil
-	}
-	item := old[n-1]
-	item.index = -1
-	*pq = old[0 : n-1]
-	return item
-}
-
-func (pq *PriorityQueue[T]) Update(item *PriorityQueueEntry[T], value T, priority int) {
-	item.Value = value
-	item.Priority = priority
-	heap.Fix(pq, item.index)
-}
-
-type FIFOQueue[T any] struct {
-	Items    []T
-	capacity int
-	size     int
-	front    int
-	rear     int
-}
-
-func NewFIFOQueue[T any](capacity int) *FIFOQueue[T] {
-	return &FIFOQueue[T]{
-		Items:    make([]T, capacity),
-		capacity: capacity,
-		front:    0,
-		rear:     0,
-		size:     0,
-	}
-}
-
-func (q *FIFOQueue[T]) Enqueue(item T) {
-	if q.size == q.capacity {
-		q.front = (q.front + 1) % q.capacity
-		q.size--
-	}
-
-	q.Items[q.rear] = item
-	q.rear = (q.rear + 1) % q.capacity
-	q.size++
-}
-
-func (q *FIFOQueue[T]) Dequeue() T {
-	if q.size == 0 {
-		var zeroValue T
-		return zeroValue
-	}
-
-	item := q.Items[q.front]
-	q.front = (q.front + 1) % q.capacity
-	q.size--
-	return item
-}
-
-func (q *FIFOQueue[T]) Front() T {
-	if q.size == 0 {
-		var zeroValue T
-		return zeroValue
-	}
-	return q.Items[q.front]
-}
-
-func (q *FIFOQueue[T]) IsEmpty() bool {
-	return q.size == 0
-}
-
-func (q *FIFOQueue[T]) Size() int {
-	return q.size
-}
-
-func (q *FIFOQueue[T]) Capacity() int {
-	return q.capacity
-}
-
-func (q *FIFOQueue[T]) String() string {
-	var sb strings.Builder
-	sb.WriteString("[")
-	for i := 0; i < q.size; i++ {
-		sb.WriteString(fmt.Sprintf("%v, ", q.Items[(q.front+i)%q.capacity]))
-	}
-	sb.WriteString("]")
-	return sb.String()
-}
-
-func Combinations[T any](slice []T, r int) [][]T {
-	n := len(slice)
-	if r > n {
-		return nil
-	}
-
-	var result [][]T
-
-	indices := make([]int, r)
-	for i := range indices {
-		indices[i] = i
-	}
-
-	addCombination(slice, indices, &result)
-
-	for {
-		i := r - 1
-		for i >= 0 && indices[i] == i+n-r {
-			i--
-		}
-
-		if i < 0 {
-			break
-		}
-
-		indices[i]++
-
-		for j := i + 1; j < r; j++ {
-			indices[j] = indices[j-1] + 1
-		}
-
-		addCombination(slice, indices, &result)
-	}
-
-	return result
-}
-
-func addCombination[T any](slice []T, indices []int, result *[][]T) {
-	var combination []T
-	for _, index := range indices {
-		combination = append(combination, slice[index])
-	}
-	*result = append(*result, combination)
-}
@@ -0,0 +1,28 @@
+package solver
+
+import (
+	"context"
+	"maps"
+)
+
+type paramsKey struct{}
+
+// WithParam returns a context that overrides a named puzzle parameter, such as the number of blinks on day 11.
+func WithParam(ctx context.Context, name string, value int) context.Context {
+	params, _ := ctx.Value(paramsKey{}).(map[string]int)
+	params = maps.Clone(params)
+	if params == nil {
+		params = make(map[string]int)
+	}
+	params[name] = value
+	return context.WithValue(ctx, paramsKey{}, params)
+}
+
+// IntParam returns the named parameter from ctx, or def when it has not been overridden.
+func IntParam(ctx context.Context, name string, def int) int {
+	params, _ := ctx.Value(paramsKey{}).(map[string]int)
+	if value, ok := params[name]; ok {
+		return value
+	}
+	return def
+}
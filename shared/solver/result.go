@@ -0,0 +1,161 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Answer is the set of types a part may return as its answer. Counts that can outgrow an int are
+// returned as a *big.Int.
+type Answer interface {
+	int | string | *big.Int
+}
+
+// Kind tells which type of answer a Result holds.
+type Kind int
+
+const (
+	KindNone Kind = iota
+	KindInt
+	KindString
+	KindBig
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindString:
+		return "string"
+	case KindBig:
+		return "big"
+	default:
+		return "none"
+	}
+}
+
+// Result is the answer to one part of a puzzle. Big answers are kept in str as decimal digits, so
+// Results stay comparable.
+type Result struct {
+	kind Kind
+	num  int
+	str  string
+}
+
+func IntResult(n int) Result {
+	return Result{kind: KindInt, num: n}
+}
+
+func StringResult(s string) Result {
+	return Result{kind: KindString, str: s}
+}
+
+// BigResult wraps an integer answer that can be too large for an int. Answers that fit are stored as an
+// int, so they equal the same answer from IntResult.
+func BigResult(n *big.Int) Result {
+	if n.IsInt64() {
+		if v := n.Int64(); int64(int(v)) == v {
+			return IntResult(int(v))
+		}
+	}
+	return Result{kind: KindBig, str: n.String()}
+}
+
+// NewResult wraps a typed answer in a Result.
+func NewResult[A Answer](answer A) Result {
+	switch v := any(answer).(type) {
+	case int:
+		return IntResult(v)
+	case string:
+		return StringResult(v)
+	case *big.Int:
+		return BigResult(v)
+	default:
+		return Result{}
+	}
+}
+
+func (r Result) Kind() Kind {
+	return r.kind
+}
+
+// Int returns the answer if it is an int.
+func (r Result) Int() (int, bool) {
+	return r.num, r.kind == KindInt
+}
+
+// BigInt returns the answer if it is an integer of any size.
+func (r Result) BigInt() (*big.Int, bool) {
+	switch r.kind {
+	case KindInt:
+		return big.NewInt(int64(r.num)), true
+	case KindBig:
+		n, ok := new(big.Int).SetString(r.str, 10)
+		return n, ok
+	default:
+		return nil, false
+	}
+}
+
+// Str returns the answer if it is a string.
+func (r Result) Str() (string, bool) {
+	return r.str, r.kind == KindString
+}
+
+func (r Result) IsNone() bool {
+	return r.kind == KindNone
+}
+
+func (r Result) Equal(other Result) bool {
+	return r == other
+}
+
+func (r Result) String() string {
+	switch r.kind {
+	case KindInt:
+		return strconv.Itoa(r.num)
+	case KindString, KindBig:
+		return r.str
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON encodes int and big answers as JSON numbers, string answers as JSON strings and no answer as null.
+func (r Result) MarshalJSON() ([]byte, error) {
+	switch r.kind {
+	case KindInt:
+		return []byte(strconv.Itoa(r.num)), nil
+	case KindBig:
+		return []byte(r.str), nil
+	case KindString:
+		return json.Marshal(r.str)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+func (r *Result) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*r = Result{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		*r = StringResult(str)
+		return nil
+	}
+
+	num, ok := new(big.Int).SetString(string(data), 10)
+	if !ok {
+		return fmt.Errorf("invalid answer %s: must be an integer or a string", data)
+	}
+	*r = BigResult(num)
+	return nil
+}
@@ -0,0 +1,44 @@
+package solver
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestBigResult(t *testing.T) {
+	if got := NewResult(big.NewInt(42)); !got.Equal(IntResult(42)) {
+		t.Errorf("NewResult(big 42) = %v (%v), want the int answer 42", got, got.Kind())
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	r := NewResult(huge)
+	if r.Kind() != KindBig || r.String() != huge.String() {
+		t.Fatalf("NewResult(%v) = %v (%v), want a big answer", huge, r, r.Kind())
+	}
+	if n, ok := r.BigInt(); !ok || n.Cmp(huge) != 0 {
+		t.Errorf("BigInt() = %v, %v, want %v", n, ok, huge)
+	}
+	if _, ok := r.Int(); ok {
+		t.Errorf("Int() reported a big answer as an int")
+	}
+}
+
+func TestResultJSONRoundTrip(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-98765432109876543210", 10)
+
+	for _, want := range []Result{IntResult(7), StringResult("co,de"), BigResult(huge), {}} {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Result
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("unmarshaling %s: %v", data, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("%v became %v after a round trip through %s", want, got, data)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ErrNoSolution is returned by parts that have no puzzle to solve, such as day 25 part 2.
+var ErrNoSolution = errors.New("no solution for this part")
+
+// Solver parses a day's puzzle input and solves both of its parts.
+// Parts must not modify the parsed input, so it can be reused between runs,
+// and must stop with the context's error once it is cancelled.
+type Solver interface {
+	Parse(r io.Reader) (any, error)
+	Part1(ctx context.Context, input any) (Result, error)
+	Part2(ctx context.Context, input any) (Result, error)
+}
+
+type solution[T any, R1 Answer, R2 Answer] struct {
+	parse func(io.Reader) (T, error)
+	part1 func(context.Context, T) (R1, error)
+	part2 func(context.Context, T) (R2, error)
+}
+
+// New builds a Solver from a day's typed parse and part functions.
+func New[T any, R1 Answer, R2 Answer](
+	parse func(io.Reader) (T, error),
+	part1 func(context.Context, T) (R1, error),
+	part2 func(context.Context, T) (R2, error),
+) Solver {
+	return &solution[T, R1, R2]{parse: parse, part1: part1, part2: part2}
+}
+
+func (s *solution[T, R1, R2]) Parse(r io.Reader) (any, error) {
+	return s.parse(r)
+}
+
+func (s *solution[T, R1, R2]) Part1(ctx context.Context, input any) (Result, error) {
+	in, err := s.typedInput(input)
+	if err != nil {
+		return Result{}, err
+	}
+	return toResult(s.part1(ctx, in))
+}
+
+func (s *solution[T, R1, R2]) Part2(ctx context.Context, input any) (Result, error) {
+	in, err := s.typedInput(input)
+	if err != nil {
+		return Result{}, err
+	}
+	return toResult(s.part2(ctx, in))
+}
+
+func toResult[A Answer](answer A, err error) (Result, error) {
+	if err != nil {
+		return Result{}, err
+	}
+	return NewResult(answer), nil
+}
+
+func (s *solution[T, R1, R2]) typedInput(input any) (T, error) {
+	in, ok := input.(T)
+	if !ok {
+		return in, fmt.Errorf("unexpected input type %T", input)
+	}
+	return in, nil
+}
+
+var registry = make(map[int]Solver)
+
+// Register makes a day's solver available. It panics if the day is registered twice.
+func Register(day int, s Solver) {
+	if _, exists := registry[day]; exists {
+		panic(fmt.Sprintf("solver: day %d registered twice", day))
+	}
+	registry[day] = s
+}
+
+// Get returns the solver registered for a day.
+func Get(day int) (Solver, bool) {
+	s, ok := registry[day]
+	return s, ok
+}
+
+// Days returns every registered day in ascending order.
+func Days() []int {
+	days := make([]int, 0, len(registry))
+	for day := range registry {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
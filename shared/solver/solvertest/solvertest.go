@@ -0,0 +1,109 @@
+// Package solvertest provides helpers for testing day solvers against inline example inputs.
+package solvertest
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"aoc2024/shared/solver"
+)
+
+// Parse parses an inline puzzle input, failing the test if parsing fails.
+func Parse[T any](t testing.TB, parse func(io.Reader) (T, error), input string) T {
+	t.Helper()
+
+	in, err := parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parsing input: %v", err)
+	}
+	return in
+}
+
+// Part parses an inline puzzle input and checks the answer part returns for it.
+func Part[T any, R solver.Answer](
+	t testing.TB,
+	parse func(io.Reader) (T, error),
+	part func(context.Context, T) (R, error),
+	input string,
+	want R,
+) {
+	t.Helper()
+
+	got, err := part(context.Background(), Parse(t, parse, input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !solver.NewResult(got).Equal(solver.NewResult(want)) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// puzzleInput is the file a day's real puzzle input is read from, relative to its package directory.
+const puzzleInput = "input.txt"
+
+type namedInput struct {
+	name  string
+	input string
+}
+
+// benchInputs returns the example input, followed by the real puzzle input when it is present.
+func benchInputs(example string) []namedInput {
+	inputs := []namedInput{{name: "example", input: example}}
+	if data, err := os.ReadFile(puzzleInput); err == nil {
+		inputs = append(inputs, namedInput{name: "input", input: string(data)})
+	}
+	return inputs
+}
+
+// BenchParse benchmarks parse on the example input and, when present, the real puzzle input.
+func BenchParse[T any](b *testing.B, parse func(io.Reader) (T, error), example string) {
+	for _, in := range benchInputs(example) {
+		b.Run(in.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := parse(strings.NewReader(in.input)); err != nil {
+					b.Fatalf("parsing input: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchPart benchmarks part on the example input and, when present, the real puzzle input.
+// Parsing is not included in the measurement.
+func BenchPart[T any, R any](b *testing.B, parse func(io.Reader) (T, error), part func(context.Context, T) (R, error), example string) {
+	for _, in := range benchInputs(example) {
+		b.Run(in.name, func(b *testing.B) {
+			benchPart(b, parse, part, in.input)
+		})
+	}
+}
+
+// BenchPartInput benchmarks part on the real puzzle input only, for parts that cannot run on the example.
+// It skips the benchmark when the puzzle input is not present.
+func BenchPartInput[T any, R any](b *testing.B, parse func(io.Reader) (T, error), part func(context.Context, T) (R, error)) {
+	data, err := os.ReadFile(puzzleInput)
+	if err != nil {
+		b.Skipf("no puzzle input: %v", err)
+	}
+
+	b.Run("input", func(b *testing.B) {
+		benchPart(b, parse, part, string(data))
+	})
+}
+
+func benchPart[T any, R any](b *testing.B, parse func(io.Reader) (T, error), part func(context.Context, T) (R, error), input string) {
+	in := Parse(b, parse, input)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := part(ctx, in); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
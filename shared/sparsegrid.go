@@ -0,0 +1,119 @@
+package shared
+
+import "fmt"
+
+// SparseGrid is a grid backed by a map, for simulations whose extent is not known up front.
+// Cells that were never set hold the fill value, coordinates may be negative, and the bounding
+// box grows with the cells that are set. A wrapping grid folds every point into a fixed
+// width x height room instead, as if its edges were joined.
+type SparseGrid[T any] struct {
+	cells  map[Point]T
+	fill   T
+	wrap   bool
+	width  int
+	height int
+	min    Point
+	max    Point
+	stale  bool
+}
+
+func NewSparseGrid[T any](fill T) *SparseGrid[T] {
+	return &SparseGrid[T]{cells: make(map[Point]T), fill: fill}
+}
+
+func NewWrappingSparseGrid[T any](width int, height int, fill T) *SparseGrid[T] {
+	g := NewSparseGrid(fill)
+	g.wrap, g.width, g.height = true, width, height
+	return g
+}
+
+// Wrap returns the point p stands for: folded into the room of a wrapping grid, or p itself otherwise.
+func (g *SparseGrid[T]) Wrap(p Point) Point {
+	if !g.wrap {
+		return p
+	}
+	return p.Wrap(g.width, g.height)
+}
+
+func (g *SparseGrid[T]) Get(p Point) T {
+	if value, ok := g.cells[g.Wrap(p)]; ok {
+		return value
+	}
+	return g.fill
+}
+
+func (g *SparseGrid[T]) Set(p Point, value T) {
+	p = g.Wrap(p)
+	if len(g.cells) == 0 && !g.stale {
+		g.min, g.max = p, p
+	}
+	g.cells[p] = value
+	g.extend(p)
+}
+
+// Delete resets p to the fill value.
+func (g *SparseGrid[T]) Delete(p Point) {
+	p = g.Wrap(p)
+	if _, ok := g.cells[p]; !ok {
+		return
+	}
+
+	delete(g.cells, p)
+	if p.X == g.min.X || p.X == g.max.X || p.Y == g.min.Y || p.Y == g.max.Y {
+		g.stale = true
+	}
+}
+
+// Contains reports whether a value was set at p.
+func (g *SparseGrid[T]) Contains(p Point) bool {
+	_, ok := g.cells[g.Wrap(p)]
+	return ok
+}
+
+func (g *SparseGrid[T]) Len() int {
+	return len(g.cells)
+}
+
+// Bounds returns the corners of the smallest box holding every set cell, or of the room of a
+// wrapping grid. ok is false when an unbounded grid has no cells set.
+func (g *SparseGrid[T]) Bounds() (min Point, max Point, ok bool) {
+	if g.wrap {
+		return NewPoint(0, 0), NewPoint(g.width-1, g.height-1), true
+	}
+
+	if g.stale {
+		g.stale = false
+		first := true
+		for p := range g.cells {
+			if first {
+				g.min, g.max, first = p, p, false
+			}
+			g.extend(p)
+		}
+	}
+	return g.min, g.max, len(g.cells) > 0
+}
+
+func (g *SparseGrid[T]) extend(p Point) {
+	g.min = NewPoint(min(g.min.X, p.X), min(g.min.Y, p.Y))
+	g.max = NewPoint(max(g.max.X, p.X), max(g.max.Y, p.Y))
+}
+
+func (g *SparseGrid[T]) Print() {
+	lo, hi, ok := g.Bounds()
+	if !ok {
+		return
+	}
+
+	for y := lo.Y; y <= hi.Y; y++ {
+		for x := lo.X; x <= hi.X; x++ {
+			switch v := any(g.Get(NewPoint(x, y))).(type) {
+			case rune:
+				fmt.Print(string(v))
+			default:
+				fmt.Print(v)
+			}
+		}
+		fmt.Println()
+	}
+}
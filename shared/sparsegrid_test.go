@@ -0,0 +1,52 @@
+package shared
+
+import "testing"
+
+func TestSparseGrid(t *testing.T) {
+	g := NewSparseGrid('.')
+
+	if _, _, ok := g.Bounds(); ok {
+		t.Errorf("empty grid has bounds")
+	}
+
+	g.Set(NewPoint(-3, 2), '#')
+	g.Set(NewPoint(4, -1), '#')
+
+	if got := g.Get(NewPoint(-3, 2)); got != '#' {
+		t.Errorf("Get(-3, 2) = %q, want '#'", got)
+	}
+	if got := g.Get(NewPoint(100, 100)); got != '.' {
+		t.Errorf("Get of an unset cell = %q, want the fill value", got)
+	}
+	if g.Contains(NewPoint(0, 0)) || !g.Contains(NewPoint(4, -1)) {
+		t.Errorf("Contains does not match the set cells")
+	}
+
+	lo, hi, _ := g.Bounds()
+	if lo != NewPoint(-3, -1) || hi != NewPoint(4, 2) {
+		t.Errorf("Bounds() = %v, %v, want (-3, -1), (4, 2)", lo, hi)
+	}
+
+	g.Delete(NewPoint(4, -1))
+	lo, hi, _ = g.Bounds()
+	if lo != NewPoint(-3, 2) || hi != NewPoint(-3, 2) || g.Len() != 1 {
+		t.Errorf("after Delete, Bounds() = %v, %v with %d cells, want a single cell at (-3, 2)", lo, hi, g.Len())
+	}
+}
+
+func TestWrappingSparseGrid(t *testing.T) {
+	g := NewWrappingSparseGrid(11, 7, 0)
+
+	g.Set(NewPoint(-1, 7), 5)
+	if got := g.Get(NewPoint(10, 0)); got != 5 {
+		t.Errorf("Get(10, 0) = %d, want the value set at (-1, 7)", got)
+	}
+	if got := g.Get(NewPoint(21, -7)); got != 5 {
+		t.Errorf("Get(21, -7) = %d, want the value set at (-1, 7)", got)
+	}
+
+	lo, hi, ok := g.Bounds()
+	if !ok || lo != NewPoint(0, 0) || hi != NewPoint(10, 6) {
+		t.Errorf("Bounds() = %v, %v, %v, want the whole room", lo, hi, ok)
+	}
+}
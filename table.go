@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"aoc2024/shared/solver"
+)
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	case d >= time.Microsecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.String()
+	}
+}
+
+func formatAnswer(part partReport) string {
+	if errors.Is(part.err, solver.ErrNoSolution) {
+		return "-"
+	}
+	if part.err != nil {
+		return "error"
+	}
+	return part.answer.String()
+}
+
+func printTable(w io.Writer, reports []dayReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tw, "Day\tParse\tPart 1\tTime\tPart 2\tTime\tTotal\t")
+
+	var parseTotal, part1Total, part2Total time.Duration
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			r.day,
+			formatDuration(r.parse),
+			formatAnswer(r.parts[0]), formatDuration(r.parts[0].duration),
+			formatAnswer(r.parts[1]), formatDuration(r.parts[1].duration),
+			formatDuration(r.total()),
+		)
+
+		parseTotal += r.parse
+		part1Total += r.parts[0].duration
+		part2Total += r.parts[1].duration
+	}
+
+	fmt.Fprintf(tw, "Total\t%s\t\t%s\t\t%s\t%s\t\n",
+		formatDuration(parseTotal),
+		formatDuration(part1Total),
+		formatDuration(part2Total),
+		formatDuration(parseTotal+part1Total+part2Total),
+	)
+
+	return tw.Flush()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"aoc2024/shared/solver"
+)
+
+type status string
+
+const (
+	statusPass     status = "PASS"
+	statusFail     status = "FAIL"
+	statusMismatch status = "MISMATCH"
+	statusMissing  status = "MISSING"
+	statusSkip     status = "SKIP"
+)
+
+type check struct {
+	day      int
+	part     int
+	status   status
+	observed solver.Result
+	expected solver.Result
+	err      error
+}
+
+func (c check) failed() bool {
+	return c.status == statusFail || c.status == statusMismatch
+}
+
+func verifyPart(day int, partNr int, part partReport, expected solver.Result) check {
+	c := check{day: day, part: partNr, observed: part.answer, expected: expected, err: part.err}
+
+	switch {
+	case errors.Is(part.err, solver.ErrNoSolution):
+		c.status = statusSkip
+	case part.err != nil:
+		c.status = statusFail
+	case expected.IsNone():
+		c.status = statusMissing
+	case !part.answer.Equal(expected):
+		c.status = statusMismatch
+	default:
+		c.status = statusPass
+	}
+	return c
+}
+
+func verifyDay(report dayReport, expected answers) []check {
+	checks := make([]check, len(report.parts))
+	for i, part := range report.parts {
+		checks[i] = verifyPart(report.day, i+1, part, expected.part(i+1))
+	}
+	return checks
+}
+
+func printChecks(w io.Writer, checks []check) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tPart\tStatus\tObserved\tExpected")
+
+	counts := make(map[status]int)
+	for _, c := range checks {
+		observed, expected := c.observed.String(), c.expected.String()
+		switch {
+		case c.status == statusFail:
+			observed = c.err.Error()
+		case c.status == statusMismatch && c.observed.Kind() != c.expected.Kind():
+			// The values may print the same, such as the int 123 and the string "123".
+			observed = fmt.Sprintf("%s (%s)", observed, c.observed.Kind())
+			expected = fmt.Sprintf("%s (%s)", expected, c.expected.Kind())
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\n", c.day, c.part, c.status, observed, expected)
+		counts[c.status]++
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed, %d mismatched, %d missing, %d skipped\n",
+		counts[statusPass], counts[statusFail], counts[statusMismatch], counts[statusMissing], counts[statusSkip])
+	return err
+}
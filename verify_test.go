@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aoc2024/shared/solver"
+)
+
+func TestVerifyPart(t *testing.T) {
+	tests := []struct {
+		name     string
+		part     partReport
+		expected solver.Result
+		want     status
+		failed   bool
+	}{
+		{name: "match", part: partReport{answer: solver.IntResult(42)}, expected: solver.IntResult(42), want: statusPass},
+		{
+			name:     "match string",
+			part:     partReport{answer: solver.StringResult("4,6,3")},
+			expected: solver.StringResult("4,6,3"),
+			want:     statusPass,
+		},
+		{
+			name:     "mismatch",
+			part:     partReport{answer: solver.IntResult(42)},
+			expected: solver.IntResult(41),
+			want:     statusMismatch,
+			failed:   true,
+		},
+		{
+			name:     "mismatch kind",
+			part:     partReport{answer: solver.IntResult(42)},
+			expected: solver.StringResult("42"),
+			want:     statusMismatch,
+			failed:   true,
+		},
+		{name: "missing", part: partReport{answer: solver.IntResult(42)}, want: statusMissing},
+		{
+			name:     "error",
+			part:     partReport{err: errors.New("boom")},
+			expected: solver.IntResult(42),
+			want:     statusFail,
+			failed:   true,
+		},
+		{name: "no solution", part: partReport{err: solver.ErrNoSolution}, want: statusSkip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := verifyPart(3, 1, tt.part, tt.expected)
+			if c.status != tt.want {
+				t.Errorf("status = %s, want %s", c.status, tt.want)
+			}
+			if c.failed() != tt.failed {
+				t.Errorf("failed() = %t, want %t", c.failed(), tt.failed)
+			}
+		})
+	}
+}
+
+func TestAnswersRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	a, err := loadAnswers(path)
+	if err != nil {
+		t.Fatalf("loading a missing file: unexpected error: %v", err)
+	}
+	if !a.Part1.IsNone() || !a.Part2.IsNone() {
+		t.Errorf("a missing file has answers %+v", a)
+	}
+
+	report := dayReport{day: 17, parts: [2]partReport{
+		{answer: solver.StringResult("4,6,3,5")},
+		{answer: solver.IntResult(117440)},
+	}}
+	if err := saveAnswers(path, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a, err = loadAnswers(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checks := verifyDay(report, a)
+	for _, c := range checks {
+		if c.status != statusPass {
+			t.Errorf("part %d is %s after recording it", c.part, c.status)
+		}
+	}
+}
+
+func TestLoadAnswersInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadAnswers(path); err == nil {
+		t.Errorf("expected an error for invalid JSON")
+	}
+}
+
+func TestPrintChecksShowsKindsOfMismatchedTypes(t *testing.T) {
+	checks := []check{
+		verifyPart(1, 1, partReport{answer: solver.IntResult(123)}, solver.StringResult("123")),
+		verifyPart(1, 2, partReport{answer: solver.IntResult(123)}, solver.IntResult(124)),
+	}
+
+	var buf bytes.Buffer
+	if err := printChecks(&buf, checks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.Contains(lines[1], "123 (int)") || !strings.Contains(lines[1], "123 (string)") {
+		t.Errorf("mismatch of different kinds does not show them: %q", lines[1])
+	}
+	if strings.Contains(lines[2], "(int)") {
+		t.Errorf("mismatch of the same kind shows it: %q", lines[2])
+	}
+}